@@ -0,0 +1,128 @@
+package allergens
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// synonyms maps each canonical allergen to the ingredient terms that carry it.
+// Matching is deliberately conservative: "coconut milk" is reported under
+// milk, since a false alarm is cheaper than a missed allergen.
+var synonyms = map[string][]string{
+	"peanut":    {"peanut", "groundnut", "arachis", "monkey nut", "satay"},
+	"tree nut":  {"almond", "cashew", "walnut", "pecan", "pistachio", "hazelnut", "macadamia", "brazil nut", "pine nut", "praline", "marzipan", "chestnut"},
+	"milk":      {"milk", "butter", "ghee", "cheese", "paneer", "cream", "yogurt", "yoghurt", "curd", "whey", "casein", "khoa", "lactose", "buttermilk"},
+	"egg":       {"egg", "mayonnaise", "mayo", "meringue", "albumen"},
+	"wheat":     {"wheat", "flour", "maida", "atta", "semolina", "sooji", "rava", "bread", "pasta", "couscous", "seitan", "barley", "rye", "spelt", "bulgur", "soy sauce"},
+	"soy":       {"soy", "soya", "tofu", "tempeh", "edamame", "miso", "tamari"},
+	"fish":      {"fish", "salmon", "tuna", "cod", "anchovy", "sardine", "mackerel", "tilapia", "pomfret", "hilsa", "trout", "haddock"},
+	"shellfish": {"shrimp", "prawn", "crab", "lobster", "crayfish", "scallop", "clam", "mussel", "oyster", "squid", "calamari"},
+	"sesame":    {"sesame", "tahini", "gingelly"},
+	"mustard":   {"mustard"},
+	"celery":    {"celery", "celeriac"},
+}
+
+// aliases folds common spellings of an allergy onto its canonical name
+var aliases = map[string]string{
+	"peanuts":     "peanut",
+	"groundnut":   "peanut",
+	"nuts":        "tree nut",
+	"tree nuts":   "tree nut",
+	"treenut":     "tree nut",
+	"dairy":       "milk",
+	"lactose":     "milk",
+	"eggs":        "egg",
+	"gluten":      "wheat",
+	"soya":        "soy",
+	"soybean":     "soy",
+	"crustacean":  "shellfish",
+	"crustaceans": "shellfish",
+	"seafood":     "shellfish",
+}
+
+// Match records an ingredient line that carries one of a user's allergens
+type Match struct {
+	Allergen   string
+	Ingredient string
+}
+
+// Canonical returns the normalized name for an allergy as users typed it
+func Canonical(allergy string) string {
+	name := strings.ToLower(strings.TrimSpace(allergy))
+	if canonical, ok := aliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// Terms returns the ingredient terms that indicate an allergen. Allergies
+// missing from the curated table match on their own name.
+func Terms(allergy string) []string {
+	name := Canonical(allergy)
+	if name == "" {
+		return nil
+	}
+	if terms, ok := synonyms[name]; ok {
+		return terms
+	}
+	return []string{name}
+}
+
+// Find reports every ingredient that carries one of the given allergies,
+// ordered by allergen and then ingredient.
+func Find(allergies, ingredients []string) []Match {
+	var matches []Match
+	for _, allergy := range allergies {
+		name := Canonical(allergy)
+		patterns := compile(Terms(name))
+		for _, ingredient := range ingredients {
+			for _, pattern := range patterns {
+				if pattern.MatchString(ingredient) {
+					matches = append(matches, Match{Allergen: name, Ingredient: ingredient})
+					break
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Allergen != matches[j].Allergen {
+			return matches[i].Allergen < matches[j].Allergen
+		}
+		return matches[i].Ingredient < matches[j].Ingredient
+	})
+	return matches
+}
+
+// SQLPatterns returns Postgres regular expressions, for use with ~*, that
+// match the same ingredient lines as Find does for the given allergies.
+func SQLPatterns(allergies []string) []string {
+	var patterns []string
+	seen := make(map[string]bool)
+	for _, allergy := range allergies {
+		for _, term := range Terms(allergy) {
+			if seen[term] {
+				continue
+			}
+			seen[term] = true
+			patterns = append(patterns, `\m`+termExpr(term)+`\M`)
+		}
+	}
+	return patterns
+}
+
+// compile builds case-insensitive, word-bounded matchers for the terms
+func compile(terms []string) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(terms))
+	for _, term := range terms {
+		patterns = append(patterns, regexp.MustCompile(`(?i)\b`+termExpr(term)+`\b`))
+	}
+	return patterns
+}
+
+// termExpr quotes a term and lets it match its plural, so "egg" finds
+// "2 eggs" but not "eggplant".
+func termExpr(term string) string {
+	return regexp.QuoteMeta(term) + `(e?s)?`
+}
@@ -0,0 +1,104 @@
+package allergens
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var findTests = []struct {
+	name        string
+	allergies   []string
+	ingredients []string
+	want        []Match
+}{
+	{
+		name:        "plurals but not longer words",
+		allergies:   []string{"egg"},
+		ingredients: []string{"2 eggs", "1 eggplant", "Egg noodles"},
+		want:        []Match{{"egg", "2 eggs"}, {"egg", "Egg noodles"}},
+	},
+	{
+		name:        "multi-word terms",
+		allergies:   []string{"tree nuts", "soy"},
+		ingredients: []string{"50 g pine nuts", "1 pineapple", "2 tbsp soy sauce", "1 peanut"},
+		want:        []Match{{"soy", "2 tbsp soy sauce"}, {"tree nut", "50 g pine nuts"}},
+	},
+	{
+		name:        "dairy folds to milk",
+		allergies:   []string{"Dairy"},
+		ingredients: []string{"2 tbsp unsalted butter", "1 cup buttermilk", "1 butternut squash", "400 ml coconut milk"},
+		want: []Match{
+			{"milk", "1 cup buttermilk"},
+			{"milk", "2 tbsp unsalted butter"},
+			{"milk", "400 ml coconut milk"},
+		},
+	},
+	{
+		name:        "gluten folds to wheat",
+		allergies:   []string{"gluten"},
+		ingredients: []string{"1 cup whole wheat flour", "100 g buckwheat", "2 tbsp soy sauce", "1 cup rice"},
+		want:        []Match{{"wheat", "1 cup whole wheat flour"}, {"wheat", "2 tbsp soy sauce"}},
+	},
+	{
+		name:        "allergy outside the table matches its name",
+		allergies:   []string{"Kiwi"},
+		ingredients: []string{"2 kiwis", "kiwifruit"},
+		want:        []Match{{"kiwi", "2 kiwis"}},
+	},
+}
+
+func TestFind(t *testing.T) {
+	for _, tt := range findTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Find(tt.allergies, tt.ingredients); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find(%q, %q) = %v; want %v", tt.allergies, tt.ingredients, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSQLPatternsMatchFind checks that SQLPatterns flags the same lines as
+// Find. Postgres' \m and \M mark the start and end of a word, which for the
+// ASCII terms in the table is Go's \b, and ~* matches case-insensitively.
+func TestSQLPatternsMatchFind(t *testing.T) {
+	bounds := strings.NewReplacer(`\m`, `\b`, `\M`, `\b`)
+	for _, tt := range findTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patterns []*regexp.Regexp
+			for _, p := range SQLPatterns(tt.allergies) {
+				patterns = append(patterns, regexp.MustCompile(`(?i)`+bounds.Replace(p)))
+			}
+
+			found := make(map[string]bool)
+			for _, m := range Find(tt.allergies, tt.ingredients) {
+				found[m.Ingredient] = true
+			}
+			for _, ingredient := range tt.ingredients {
+				matched := false
+				for _, p := range patterns {
+					matched = matched || p.MatchString(ingredient)
+				}
+				if matched != found[ingredient] {
+					t.Errorf("%q: SQL patterns match %v, Find matches %v", ingredient, matched, found[ingredient])
+				}
+			}
+		})
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := map[string]string{
+		"Dairy":      "milk",
+		" gluten ":   "wheat",
+		"Tree Nuts":  "tree nut",
+		"crustacean": "shellfish",
+		"sesame":     "sesame",
+	}
+	for allergy, want := range tests {
+		if got := Canonical(allergy); got != want {
+			t.Errorf("Canonical(%q) = %q; want %q", allergy, got, want)
+		}
+	}
+}
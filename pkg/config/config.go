@@ -0,0 +1,416 @@
+// Package config loads the settings shared by the Go services: the address
+// each service listens on, the address its peers dial, and the database
+// connection. Built-in defaults are overridden by an optional YAML file,
+// named by SPICEROUTE_CONFIG, and then by environment variables.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Service is where one service listens and where its clients reach it
+type Service struct {
+	Listen  string `yaml:"listen"`
+	Address string `yaml:"address"`
+}
+
+// Database holds the database connection settings
+type Database struct {
+	DSN string `yaml:"dsn"`
+	// ReplicaDSNs are read replicas for read-heavy queries; when empty
+	// those reads go to the primary
+	ReplicaDSNs []string `yaml:"replica_dsns"`
+
+	// Connection pool settings; zero means unlimited
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+
+	// ConnectTimeout is how long to keep retrying the first connection
+	// while Postgres is not ready yet
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+	// LogLevel is the SQL log level: silent, error, warn or info
+	LogLevel string `yaml:"log_level"`
+}
+
+// logLevels are the accepted values of Database.LogLevel
+var logLevels = map[string]bool{"silent": true, "error": true, "warn": true, "info": true}
+
+// GRPC holds settings shared by the gRPC servers
+type GRPC struct {
+	// Reflection registers the server reflection service, for tools like
+	// grpcurl; it is off by default
+	Reflection bool `yaml:"reflection"`
+}
+
+// Auth holds the gateway's JWT verification settings. Tokens are signed
+// either with a shared HS256 secret or with RS256 keys from a JWKS file.
+type Auth struct {
+	// Disabled turns authentication off, for local development only
+	Disabled  bool   `yaml:"disabled"`
+	JWTSecret string `yaml:"jwt_secret"`
+	JWKSFile  string `yaml:"jwks_file"`
+	// Issuer and Audience, when set, must match the token's claims
+	Issuer   string `yaml:"issuer"`
+	Audience string `yaml:"audience"`
+	// AdminRole is the role that may act on any user's data
+	AdminRole string `yaml:"admin_role"`
+}
+
+// Vector holds the vector service's embedding settings
+type Vector struct {
+	// Embedder turns recipe text into vectors: hashing, which works offline,
+	// or openai
+	Embedder string `yaml:"embedder"`
+	// Dimensions is the vector size; zero uses the embedder's default
+	Dimensions int `yaml:"dimensions"`
+	// OpenAIModel and OpenAIKey configure the openai embedder
+	OpenAIModel string `yaml:"openai_model"`
+	OpenAIKey   string `yaml:"openai_api_key"`
+	// SyncInterval is how often new, changed and deleted recipes are
+	// brought into the index
+	SyncInterval time.Duration `yaml:"sync_interval"`
+}
+
+// embedders are the accepted values of Vector.Embedder
+var embedders = map[string]bool{"hashing": true, "openai": true}
+
+// Config is the configuration of one service process
+type Config struct {
+	Database Database           `yaml:"database"`
+	GRPC     GRPC               `yaml:"grpc"`
+	Auth     Auth               `yaml:"auth"`
+	Vector   Vector             `yaml:"vector"`
+	Services map[string]Service `yaml:"services"`
+
+	// name is the service this process runs as
+	name string
+}
+
+// defaults lists every service with its standard ports. The gateway is
+// only ever dialed over HTTP, so it has no gRPC address.
+var defaults = map[string]Service{
+	"gateway":  {Listen: ":8080"},
+	"profile":  {Listen: ":50051", Address: "profile:50051"},
+	"planner":  {Listen: ":50052", Address: "planner:50052"},
+	"recipes":  {Listen: ":50053", Address: "recipes:50053"},
+	"vector":   {Listen: ":50054", Address: "vector:50054"},
+	"feedback": {Listen: ":50056", Address: "feedback:50056"},
+	"plans":    {Listen: ":50057", Address: "plans:50057"},
+	"shopping": {Listen: ":50058", Address: "shopping:50058"},
+}
+
+// Load builds the configuration for the named service and validates it.
+// Command-line tools that do not serve pass an empty name. For each
+// service NAME the environment variables NAME_LISTEN_ADDR and NAME_ADDR
+// override its listen and dial addresses. DB_DSN sets the database
+// connection string and the other DB_* variables tune the connection (see
+// Database). GRPC_REFLECTION toggles server reflection, and JWT_* and
+// AUTH_DISABLED configure the gateway's authentication (see Auth), and
+// VECTOR_* and OPENAI_* configure the vector service's embedder (see Vector).
+func Load(name string) (*Config, error) {
+	if _, ok := defaults[name]; name != "" && !ok {
+		return nil, fmt.Errorf("unknown service %q", name)
+	}
+
+	cfg := &Config{
+		Database: Database{
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnectTimeout:  30 * time.Second,
+			LogLevel:        "warn",
+		},
+		Auth: Auth{AdminRole: "admin"},
+		Vector: Vector{
+			Embedder:     "hashing",
+			OpenAIModel:  "text-embedding-3-small",
+			SyncInterval: time.Minute,
+		},
+		Services: make(map[string]Service, len(defaults)),
+		name:     name,
+	}
+	for svc, d := range defaults {
+		cfg.Services[svc] = d
+	}
+
+	if path := os.Getenv("SPICEROUTE_CONFIG"); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// loadFile overlays the settings in a YAML file. Unknown keys and services
+// are rejected so that typos fail at startup instead of being ignored.
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	// Structs are decoded over the current values so omitted keys keep
+	// their defaults; services are merged field by field below
+	file := Config{Database: c.Database, GRPC: c.GRPC, Auth: c.Auth, Vector: c.Vector}
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	c.Database = file.Database
+	c.GRPC = file.GRPC
+	c.Auth = file.Auth
+	c.Vector = file.Vector
+	for svc, override := range file.Services {
+		current, ok := c.Services[svc]
+		if !ok {
+			return fmt.Errorf("config file %s: unknown service %q", path, svc)
+		}
+		if override.Listen != "" {
+			current.Listen = override.Listen
+		}
+		if override.Address != "" {
+			current.Address = override.Address
+		}
+		c.Services[svc] = current
+	}
+	return nil
+}
+
+// loadEnv overlays settings from environment variables
+func (c *Config) loadEnv() error {
+	db := &c.Database
+	if dsn := os.Getenv("DB_DSN"); dsn != "" {
+		db.DSN = dsn
+	}
+	if v := os.Getenv("DB_REPLICA_DSNS"); v != "" {
+		db.ReplicaDSNs = strings.Split(v, ",")
+	}
+	if v := os.Getenv("DB_LOG_LEVEL"); v != "" {
+		db.LogLevel = strings.ToLower(v)
+	}
+
+	var errs []error
+	errs = append(errs,
+		envInt("DB_MAX_OPEN_CONNS", &db.MaxOpenConns),
+		envInt("DB_MAX_IDLE_CONNS", &db.MaxIdleConns),
+		envDuration("DB_CONN_MAX_LIFETIME", &db.ConnMaxLifetime),
+		envDuration("DB_CONN_MAX_IDLE_TIME", &db.ConnMaxIdleTime),
+		envDuration("DB_CONNECT_TIMEOUT", &db.ConnectTimeout),
+	)
+
+	errs = append(errs,
+		envBool("GRPC_REFLECTION", &c.GRPC.Reflection),
+		envBool("AUTH_DISABLED", &c.Auth.Disabled),
+	)
+	envString("JWT_SECRET", &c.Auth.JWTSecret)
+	envString("JWT_JWKS_FILE", &c.Auth.JWKSFile)
+	envString("JWT_ISSUER", &c.Auth.Issuer)
+	envString("JWT_AUDIENCE", &c.Auth.Audience)
+	envString("JWT_ADMIN_ROLE", &c.Auth.AdminRole)
+
+	errs = append(errs,
+		envInt("VECTOR_DIMENSIONS", &c.Vector.Dimensions),
+		envDuration("VECTOR_SYNC_INTERVAL", &c.Vector.SyncInterval),
+	)
+	if v := os.Getenv("VECTOR_EMBEDDER"); v != "" {
+		c.Vector.Embedder = strings.ToLower(v)
+	}
+	envString("OPENAI_EMBEDDING_MODEL", &c.Vector.OpenAIModel)
+	envString("OPENAI_API_KEY", &c.Vector.OpenAIKey)
+
+	for svc, current := range c.Services {
+		prefix := strings.ToUpper(svc)
+		if v := os.Getenv(prefix + "_LISTEN_ADDR"); v != "" {
+			current.Listen = v
+		}
+		if v := os.Getenv(prefix + "_ADDR"); v != "" {
+			current.Address = v
+		}
+		c.Services[svc] = current
+	}
+	return errors.Join(errs...)
+}
+
+// envString sets *dst from an environment variable, if set
+func envString(key string, dst *string) {
+	if v := os.Getenv(key); v != "" {
+		*dst = v
+	}
+}
+
+// envBool sets *dst from a boolean environment variable, if set
+func envBool(key string, dst *bool) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("%s %q is not a boolean", key, v)
+	}
+	*dst = b
+	return nil
+}
+
+// envInt sets *dst from an integer environment variable, if set
+func envInt(key string, dst *int) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("%s %q is not an integer", key, v)
+	}
+	*dst = n
+	return nil
+}
+
+// envDuration sets *dst from a duration environment variable such as
+// "30s", if set
+func envDuration(key string, dst *time.Duration) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("%s %q is not a duration", key, v)
+	}
+	*dst = d
+	return nil
+}
+
+// Validate checks that every listen and dial address is a usable host:port
+// and that the database settings are in range
+func (c *Config) Validate() error {
+	names := make([]string, 0, len(c.Services))
+	for svc := range c.Services {
+		names = append(names, svc)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	if err := c.Database.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	// Only the gateway verifies tokens
+	if c.name == "gateway" && !c.Auth.Disabled && c.Auth.JWTSecret == "" && c.Auth.JWKSFile == "" {
+		errs = append(errs, fmt.Errorf("auth needs jwt_secret or jwks_file, or auth.disabled for local development"))
+	}
+	if err := c.Vector.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	// Only the vector service embeds
+	if c.name == "vector" && c.Vector.Embedder == "openai" && c.Vector.OpenAIKey == "" {
+		errs = append(errs, fmt.Errorf("the openai embedder needs openai_api_key"))
+	}
+	for _, svc := range names {
+		s := c.Services[svc]
+
+		if err := checkAddr(s.Listen, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s listen address: %w", svc, err))
+		}
+
+		if svc == "gateway" && s.Address == "" {
+			continue
+		}
+		if err := checkAddr(s.Address, true); err != nil {
+			errs = append(errs, fmt.Errorf("%s address: %w", svc, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d Database) validate() error {
+	var errs []error
+	if d.MaxOpenConns < 0 || d.MaxIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("database connection limits must not be negative"))
+	}
+	if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("database max_idle_conns %d exceeds max_open_conns %d", d.MaxIdleConns, d.MaxOpenConns))
+	}
+	if d.ConnMaxLifetime < 0 || d.ConnMaxIdleTime < 0 || d.ConnectTimeout < 0 {
+		errs = append(errs, fmt.Errorf("database durations must not be negative"))
+	}
+	if !logLevels[d.LogLevel] {
+		errs = append(errs, fmt.Errorf("database log_level %q must be one of silent, error, warn, info", d.LogLevel))
+	}
+	for i, dsn := range d.ReplicaDSNs {
+		if strings.TrimSpace(dsn) == "" {
+			errs = append(errs, fmt.Errorf("database replica %d has an empty DSN", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (v Vector) validate() error {
+	var errs []error
+	if !embedders[v.Embedder] {
+		errs = append(errs, fmt.Errorf("vector embedder %q must be one of hashing, openai", v.Embedder))
+	}
+	if v.Dimensions < 0 {
+		errs = append(errs, fmt.Errorf("vector dimensions must not be negative"))
+	}
+	if v.SyncInterval <= 0 {
+		errs = append(errs, fmt.Errorf("vector sync_interval must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// checkAddr checks that addr is a host:port pair with a valid port. Dial
+// addresses must name a host; listen addresses may leave it empty.
+func checkAddr(addr string, needHost bool) error {
+	if addr == "" {
+		return fmt.Errorf("address is empty")
+	}
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("%q: %w", addr, err)
+	}
+	if needHost && host == "" {
+		return fmt.Errorf("%q has no host", addr)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("%q has an invalid port", addr)
+	}
+	return nil
+}
+
+// Name returns the service the configuration was loaded for
+func (c *Config) Name() string {
+	return c.name
+}
+
+// ListenAddr returns the address this service should listen on
+func (c *Config) ListenAddr() string {
+	return c.Services[c.name].Listen
+}
+
+// Address returns the address clients dial to reach the named service
+func (c *Config) Address(service string) string {
+	return c.Services[service].Address
+}
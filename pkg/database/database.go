@@ -1,50 +1,141 @@
 package database
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"math/rand"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
-	"spiceroute/pkg/models"
+	"spiceroute/pkg/config"
+	"spiceroute/pkg/ingredients"
+	"spiceroute/pkg/nutrition"
 )
 
-// NewConnection creates a new GORM database connection
-func NewConnection() (*gorm.DB, error) {
-	dsn := os.Getenv("DB_DSN")
-	if dsn == "" {
-		return nil, fmt.Errorf("DB_DSN environment variable is required")
+const (
+	// initialBackoff and maxBackoff bound the wait between connection
+	// attempts while Postgres is starting
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+// logLevels maps config log levels to GORM's
+var logLevels = map[string]logger.LogLevel{
+	"silent": logger.Silent,
+	"error":  logger.Error,
+	"warn":   logger.Warn,
+	"info":   logger.Info,
+}
+
+// NewConnection creates a new GORM connection to the primary database,
+// retrying with backoff for up to cfg.ConnectTimeout while it is not ready
+func NewConnection(cfg config.Database) (*gorm.DB, error) {
+	if cfg.DSN == "" {
+		return nil, fmt.Errorf("database DSN is required; set DB_DSN or database.dsn in the config file")
 	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	return connect(cfg, cfg.DSN)
+}
+
+// NewReadConnection creates a connection for read-heavy queries that can
+// tolerate replication lag. It picks one of the configured replicas at
+// random, trying the others if it is unreachable, so load spreads across
+// service instances. Without replicas it returns primary.
+func NewReadConnection(cfg config.Database, primary *gorm.DB) (*gorm.DB, error) {
+	if len(cfg.ReplicaDSNs) == 0 {
+		return primary, nil
 	}
 
-	return db, nil
+	var errs []error
+	start := rand.Intn(len(cfg.ReplicaDSNs))
+	for i := range cfg.ReplicaDSNs {
+		dsn := cfg.ReplicaDSNs[(start+i)%len(cfg.ReplicaDSNs)]
+		db, err := connect(cfg, dsn)
+		if err == nil {
+			return db, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, fmt.Errorf("no read replica is reachable: %w", errors.Join(errs...))
 }
 
-// AutoMigrate runs database migrations for all models
-func AutoMigrate(db *gorm.DB) error {
-	log.Println("Running database migrations...")
+// connect opens dsn with the configured logging and pool settings
+func connect(cfg config.Database, dsn string) (*gorm.DB, error) {
+	gormConfig := &gorm.Config{
+		Logger: logger.Default.LogMode(logLevels[cfg.LogLevel]),
+	}
+
+	var db *gorm.DB
+	var err error
+	deadline := time.Now().Add(cfg.ConnectTimeout)
+	backoff := initialBackoff
+	for {
+		db, err = gorm.Open(postgres.Open(dsn), gormConfig)
+		if err == nil {
+			break
+		}
+		if time.Now().Add(backoff).After(deadline) {
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		log.Printf("Database not ready, retrying in %s: %v", backoff, err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxBackoff)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure connection pool: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
-	err := db.AutoMigrate(
-		&models.User{},
-		&models.Preference{},
-		&models.Recipe{},
-		&models.Feedback{},
-	)
+	return db, nil
+}
 
+// BackfillNutrition parses the free-text Nutrition label of recipes that have
+// no structured facts yet. Labels that cannot be parsed are left untouched.
+// Like every migration it uses plain SQL against the columns as they were
+// when it shipped, so replaying it does not depend on today's models.
+func BackfillNutrition(db *gorm.DB) error {
+	var recipes []struct {
+		ID        string
+		Nutrition string
+	}
+	err := db.Raw(`SELECT id, nutrition FROM recipes
+		WHERE deleted_at IS NULL AND nutrition_facts IS NULL AND nutrition <> ''`).
+		Scan(&recipes).Error
 	if err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
+		return err
+	}
+
+	parsed := 0
+	for _, recipe := range recipes {
+		facts, ok := nutrition.Parse(recipe.Nutrition)
+		if !ok {
+			continue
+		}
+		doc, err := json.Marshal(facts)
+		if err != nil {
+			return fmt.Errorf("failed to encode nutrition for recipe %s: %w", recipe.ID, err)
+		}
+		err = db.Exec(`UPDATE recipes SET nutrition_facts = ?, updated_at = now() WHERE id = ?`,
+			string(doc), recipe.ID).Error
+		if err != nil {
+			return fmt.Errorf("failed to backfill nutrition for recipe %s: %w", recipe.ID, err)
+		}
+		parsed++
 	}
 
-	log.Println("Database migrations completed successfully")
+	if len(recipes) > 0 {
+		log.Printf("Backfilled nutrition facts for %d of %d recipes", parsed, len(recipes))
+	}
 	return nil
 }
 
@@ -56,3 +147,43 @@ func CloseConnection(db *gorm.DB) error {
 	}
 	return sqlDB.Close()
 }
+
+// BackfillIngredients parses the free-text ingredient lines of recipes that
+// have no structured ingredient rows yet. It uses plain SQL for the same
+// reason as BackfillNutrition.
+func BackfillIngredients(db *gorm.DB) error {
+	var lines []struct {
+		RecipeID string
+		Position int32
+		Line     string
+	}
+	err := db.Raw(`SELECT r.id AS recipe_id, l.pos - 1 AS position, l.line
+		FROM recipes r, unnest(r.ingredients) WITH ORDINALITY AS l(line, pos)
+		WHERE r.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM recipe_ingredients ri WHERE ri.recipe_id = r.id)
+		ORDER BY r.id, l.pos`).
+		Scan(&lines).Error
+	if err != nil {
+		return err
+	}
+
+	recipes := 0
+	for i, line := range lines {
+		ing := ingredients.Parse(line.Line)
+		err := db.Exec(`INSERT INTO recipe_ingredients
+			(recipe_id, "position", name, quantity, unit, note, canonical_id, raw, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, now(), now())`,
+			line.RecipeID, line.Position, ing.Name, ing.Quantity, ing.Unit, ing.Note, ing.CanonicalID, ing.Raw).Error
+		if err != nil {
+			return fmt.Errorf("failed to backfill ingredients for recipe %s: %w", line.RecipeID, err)
+		}
+		if i == 0 || lines[i-1].RecipeID != line.RecipeID {
+			recipes++
+		}
+	}
+
+	if recipes > 0 {
+		log.Printf("Backfilled structured ingredients for %d recipes", recipes)
+	}
+	return nil
+}
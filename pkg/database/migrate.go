@@ -0,0 +1,240 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationLockID is the Postgres advisory lock key held while migrating,
+// so concurrent migrate runs apply each migration exactly once
+const migrationLockID int64 = 727_001
+
+// Migration is one versioned, reversible schema change. Up and Down run
+// inside a transaction together with the schema_migrations bookkeeping.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// schemaMigration is a row of the schema_migrations table
+type schemaMigration struct {
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// LatestVersion returns the version of the newest known migration
+func LatestVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// MigrateUp applies every pending migration in order
+func MigrateUp(db *gorm.DB) error {
+	return withMigrationLock(db, func(conn *gorm.DB) error {
+		applied, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		pending := 0
+		for _, m := range migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			if err := apply(conn, m); err != nil {
+				return err
+			}
+			pending++
+		}
+
+		if pending == 0 {
+			log.Printf("Schema is up to date at version %d", LatestVersion())
+		}
+		return nil
+	})
+}
+
+// MigrateDown reverts the most recently applied migrations, newest first
+func MigrateDown(db *gorm.DB, steps int) error {
+	return withMigrationLock(db, func(conn *gorm.DB) error {
+		applied, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			m := migrations[i]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			if err := revert(conn, m); err != nil {
+				return err
+			}
+			steps--
+		}
+		return nil
+	})
+}
+
+// Redo reverts the most recently applied migration and applies it again
+func Redo(db *gorm.DB) error {
+	return withMigrationLock(db, func(conn *gorm.DB) error {
+		current, err := currentVersion(conn)
+		if err != nil {
+			return err
+		}
+		if current == 0 {
+			return fmt.Errorf("no migration has been applied")
+		}
+
+		m, ok := findMigration(current)
+		if !ok {
+			return fmt.Errorf("applied migration %d is unknown to this build", current)
+		}
+		if err := revert(conn, m); err != nil {
+			return err
+		}
+		return apply(conn, m)
+	})
+}
+
+// Status lists every known migration and whether it has been applied
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		row, ok := applied[m.Version]
+		statuses = append(statuses, MigrationStatus{
+			Version:   m.Version,
+			Name:      m.Name,
+			Applied:   ok,
+			AppliedAt: row.AppliedAt,
+		})
+	}
+	return statuses, nil
+}
+
+// VerifySchema checks at service startup that every migration this build
+// knows about has been applied. A newer schema is tolerated so that old
+// instances keep running during a rolling deploy.
+func VerifySchema(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return fmt.Errorf("database has no schema_migrations table; run scripts/migrate.go up")
+	}
+
+	current, err := currentVersion(db)
+	if err != nil {
+		return err
+	}
+	if current < LatestVersion() {
+		return fmt.Errorf("database schema is at version %d but this build needs %d; run scripts/migrate.go up",
+			current, LatestVersion())
+	}
+	if current > LatestVersion() {
+		log.Printf("Database schema version %d is newer than this build's %d", current, LatestVersion())
+	}
+	return nil
+}
+
+// withMigrationLock runs fn on a single connection holding the migration
+// advisory lock
+func withMigrationLock(db *gorm.DB, fn func(conn *gorm.DB) error) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID)
+
+		if err := conn.AutoMigrate(&schemaMigration{}); err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+		return fn(conn)
+	})
+}
+
+// apply runs a migration's Up step and records it
+func apply(db *gorm.DB, m Migration) error {
+	log.Printf("Applying migration %d_%s", m.Version, m.Name)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := m.Up(tx); err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d_%s failed: %w", m.Version, m.Name, err)
+	}
+	return nil
+}
+
+// revert runs a migration's Down step and forgets it
+func revert(db *gorm.DB, m Migration) error {
+	log.Printf("Reverting migration %d_%s", m.Version, m.Name)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := m.Down(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&schemaMigration{}, m.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("reverting migration %d_%s failed: %w", m.Version, m.Name, err)
+	}
+	return nil
+}
+
+// appliedVersions returns the recorded migrations keyed by version
+func appliedVersions(db *gorm.DB) (map[int]schemaMigration, error) {
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	applied := make(map[int]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// currentVersion returns the highest applied migration, or 0 for none
+func currentVersion(db *gorm.DB) (int, error) {
+	var version int
+	err := db.Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+func findMigration(version int) (Migration, bool) {
+	for _, m := range migrations {
+		if m.Version == version {
+			return m, true
+		}
+	}
+	return Migration{}, false
+}
@@ -0,0 +1,365 @@
+package database
+
+import "gorm.io/gorm"
+
+// migrations lists every schema change in the order it is applied. Never
+// edit or reorder a migration that has shipped; append a new one instead.
+// Statements use IF [NOT] EXISTS so databases created by the old
+// AutoMigrate setup adopt the baseline without errors.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "create_core_tables",
+		Up: execSQL(
+			`CREATE TABLE IF NOT EXISTS users (
+				id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at)`,
+
+			`CREATE TABLE IF NOT EXISTS preferences (
+				id bigserial PRIMARY KEY,
+				user_id uuid NOT NULL,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				cuisines text[],
+				allergies text[],
+				budget_week decimal,
+				spicy boolean,
+				CONSTRAINT fk_users_preferences FOREIGN KEY (user_id) REFERENCES users (id)
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_preferences_user_id ON preferences (user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_preferences_deleted_at ON preferences (deleted_at)`,
+
+			`CREATE TABLE IF NOT EXISTS recipes (
+				id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+				name text NOT NULL,
+				cuisine text,
+				prep_minutes integer,
+				calories integer,
+				ingredients text[],
+				cost decimal,
+				shelf_life_days integer,
+				tags text[],
+				nutrition text,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_recipes_deleted_at ON recipes (deleted_at)`,
+
+			`CREATE TABLE IF NOT EXISTS feedback (
+				id bigserial PRIMARY KEY,
+				user_id uuid NOT NULL,
+				dish_id uuid NOT NULL,
+				rating integer,
+				skipped boolean,
+				substituted_with text,
+				comment text,
+				cooked_at timestamptz,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				CONSTRAINT fk_users_feedback FOREIGN KEY (user_id) REFERENCES users (id),
+				CONSTRAINT fk_recipes_feedback FOREIGN KEY (dish_id) REFERENCES recipes (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_feedback_deleted_at ON feedback (deleted_at)`,
+		),
+		Down: execSQL(
+			`DROP TABLE IF EXISTS feedback`,
+			`DROP TABLE IF EXISTS recipes`,
+			`DROP TABLE IF EXISTS preferences`,
+			`DROP TABLE IF EXISTS users`,
+		),
+	},
+	{
+		Version: 2,
+		Name:    "add_structured_recipe_data",
+		Up: execSQL(
+			`ALTER TABLE recipes ADD COLUMN IF NOT EXISTS nutrition_facts jsonb`,
+			`CREATE TABLE IF NOT EXISTS recipe_ingredients (
+				id bigserial PRIMARY KEY,
+				recipe_id uuid NOT NULL,
+				"position" integer,
+				name text NOT NULL,
+				quantity decimal,
+				unit text,
+				note text,
+				canonical_id text,
+				raw text,
+				created_at timestamptz,
+				updated_at timestamptz,
+				CONSTRAINT fk_recipes_structured_ingredients FOREIGN KEY (recipe_id) REFERENCES recipes (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_recipe_ingredients_recipe_id ON recipe_ingredients (recipe_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_recipe_ingredients_canonical_id ON recipe_ingredients (canonical_id)`,
+		),
+		Down: execSQL(
+			`DROP TABLE IF EXISTS recipe_ingredients`,
+			`ALTER TABLE recipes DROP COLUMN IF EXISTS nutrition_facts`,
+		),
+	},
+	{
+		// Data only: reverting leaves the parsed facts in place
+		Version: 3,
+		Name:    "backfill_nutrition_facts",
+		Up:      BackfillNutrition,
+		Down:    noop,
+	},
+	{
+		Version: 4,
+		Name:    "backfill_recipe_ingredients",
+		Up:      BackfillIngredients,
+		Down:    noop,
+	},
+	{
+		Version: 5,
+		Name:    "create_meal_plans",
+		Up: execSQL(
+			`CREATE TABLE IF NOT EXISTS meal_plans (
+				id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+				user_id uuid NOT NULL,
+				week_start date,
+				days integer,
+				daily_calories decimal,
+				budget_week decimal,
+				dish_ids text[],
+				cook_days text[],
+				shopping_list text[],
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				CONSTRAINT fk_users_plans FOREIGN KEY (user_id) REFERENCES users (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_meal_plans_user_id ON meal_plans (user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_meal_plans_week_start ON meal_plans (week_start)`,
+			`CREATE INDEX IF NOT EXISTS idx_meal_plans_deleted_at ON meal_plans (deleted_at)`,
+
+			`CREATE TABLE IF NOT EXISTS plan_entries (
+				id bigserial PRIMARY KEY,
+				plan_id uuid NOT NULL,
+				day_index integer,
+				"position" integer,
+				dish_id uuid NOT NULL,
+				servings integer,
+				CONSTRAINT fk_meal_plans_entries FOREIGN KEY (plan_id) REFERENCES meal_plans (id),
+				CONSTRAINT fk_plan_entries_recipe FOREIGN KEY (dish_id) REFERENCES recipes (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_plan_entries_plan_id ON plan_entries (plan_id)`,
+		),
+		Down: execSQL(
+			`DROP TABLE IF EXISTS plan_entries`,
+			`DROP TABLE IF EXISTS meal_plans`,
+		),
+	},
+	{
+		Version: 6,
+		Name:    "create_shopping_lists",
+		Up: execSQL(
+			`CREATE TABLE IF NOT EXISTS shopping_lists (
+				id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+				user_id uuid NOT NULL,
+				plan_id uuid,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				CONSTRAINT fk_shopping_lists_plan FOREIGN KEY (plan_id) REFERENCES meal_plans (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_shopping_lists_user_id ON shopping_lists (user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_shopping_lists_plan_id ON shopping_lists (plan_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_shopping_lists_deleted_at ON shopping_lists (deleted_at)`,
+
+			`CREATE TABLE IF NOT EXISTS shopping_items (
+				id bigserial PRIMARY KEY,
+				list_id uuid NOT NULL,
+				canonical_id text,
+				name text NOT NULL,
+				quantity decimal,
+				unit text,
+				category text,
+				checked boolean,
+				manual boolean,
+				created_at timestamptz,
+				updated_at timestamptz,
+				CONSTRAINT fk_shopping_lists_items FOREIGN KEY (list_id) REFERENCES shopping_lists (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_shopping_items_list_id ON shopping_items (list_id)`,
+		),
+		Down: execSQL(
+			`DROP TABLE IF EXISTS shopping_items`,
+			`DROP TABLE IF EXISTS shopping_lists`,
+		),
+	},
+	{
+		Version: 7,
+		Name:    "create_pantry_items",
+		Up: execSQL(
+			`ALTER TABLE shopping_items ADD COLUMN IF NOT EXISTS from_pantry decimal`,
+			`CREATE TABLE IF NOT EXISTS pantry_items (
+				id bigserial PRIMARY KEY,
+				user_id uuid NOT NULL,
+				canonical_id text,
+				name text NOT NULL,
+				quantity decimal,
+				unit text,
+				expires_on date,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				CONSTRAINT fk_pantry_items_user FOREIGN KEY (user_id) REFERENCES users (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_pantry_items_user_id ON pantry_items (user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_pantry_items_canonical_id ON pantry_items (canonical_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_pantry_items_deleted_at ON pantry_items (deleted_at)`,
+		),
+		Down: execSQL(
+			`DROP TABLE IF EXISTS pantry_items`,
+			`ALTER TABLE shopping_items DROP COLUMN IF EXISTS from_pantry`,
+		),
+	},
+	{
+		Version: 8,
+		Name:    "index_feedback_lookups",
+		Up: execSQL(
+			`CREATE INDEX IF NOT EXISTS idx_feedback_user_id ON feedback (user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_feedback_dish_id ON feedback (dish_id)`,
+		),
+		Down: execSQL(
+			`DROP INDEX IF EXISTS idx_feedback_dish_id`,
+			`DROP INDEX IF EXISTS idx_feedback_user_id`,
+		),
+	},
+	{
+		Version: 9,
+		Name:    "add_user_profile",
+		Up: execSQL(
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS email text`,
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS name text`,
+			// Deleted accounts release their address for a new sign-up
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (lower(email)) WHERE deleted_at IS NULL`,
+		),
+		Down: execSQL(
+			`DROP INDEX IF EXISTS idx_users_email`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS name`,
+			`ALTER TABLE users DROP COLUMN IF EXISTS email`,
+		),
+	},
+	{
+		Version: 10,
+		Name:    "extend_preferences",
+		Up: execSQL(
+			`ALTER TABLE preferences ADD COLUMN IF NOT EXISTS diet text`,
+			`ALTER TABLE preferences ADD COLUMN IF NOT EXISTS disliked_ingredients text[]`,
+			`ALTER TABLE preferences ADD COLUMN IF NOT EXISTS household_size integer`,
+			`ALTER TABLE preferences ADD COLUMN IF NOT EXISTS members jsonb`,
+			`ALTER TABLE preferences ADD COLUMN IF NOT EXISTS spice_level integer`,
+			`ALTER TABLE preferences ADD COLUMN IF NOT EXISTS max_weeknight_prep_minutes integer`,
+			`ALTER TABLE preferences ADD COLUMN IF NOT EXISTS skill_level text`,
+			// Carry the old flag over to the new scale
+			`UPDATE preferences SET spice_level = 3 WHERE spicy AND spice_level IS NULL`,
+		),
+		Down: execSQL(
+			`ALTER TABLE preferences DROP COLUMN IF EXISTS skill_level`,
+			`ALTER TABLE preferences DROP COLUMN IF EXISTS max_weeknight_prep_minutes`,
+			`ALTER TABLE preferences DROP COLUMN IF EXISTS spice_level`,
+			`ALTER TABLE preferences DROP COLUMN IF EXISTS members`,
+			`ALTER TABLE preferences DROP COLUMN IF EXISTS household_size`,
+			`ALTER TABLE preferences DROP COLUMN IF EXISTS disliked_ingredients`,
+			`ALTER TABLE preferences DROP COLUMN IF EXISTS diet`,
+		),
+	},
+	{
+		Version: 11,
+		Name:    "create_moods",
+		Up: execSQL(
+			`CREATE TABLE IF NOT EXISTS moods (
+				id bigserial PRIMARY KEY,
+				user_id uuid NOT NULL,
+				week_start date NOT NULL,
+				cuisines text[],
+				exclusive boolean,
+				expires_at timestamptz NOT NULL,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				CONSTRAINT fk_moods_user FOREIGN KEY (user_id) REFERENCES users (id)
+			)`,
+			// One live mood per user and week; cleared moods stay for history
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_moods_user_week ON moods (user_id, week_start) WHERE deleted_at IS NULL`,
+			`CREATE INDEX IF NOT EXISTS idx_moods_user_id ON moods (user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_moods_deleted_at ON moods (deleted_at)`,
+		),
+		Down: execSQL(
+			`DROP TABLE IF EXISTS moods`,
+		),
+	},
+	{
+		Version: 12,
+		Name:    "add_recipe_search",
+		Up: execSQL(
+			`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+			// array_to_string is only STABLE, which generated columns refuse
+			`CREATE OR REPLACE FUNCTION recipe_search_text(items text[]) RETURNS text
+				LANGUAGE sql IMMUTABLE PARALLEL SAFE
+				AS $$ SELECT coalesce(array_to_string(items, ' '), '') $$`,
+			`ALTER TABLE recipes ADD COLUMN IF NOT EXISTS search_vector tsvector
+				GENERATED ALWAYS AS (
+					setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+					setweight(to_tsvector('english', coalesce(cuisine, '')), 'B') ||
+					setweight(to_tsvector('english', recipe_search_text(tags)), 'B') ||
+					setweight(to_tsvector('english', recipe_search_text(ingredients)), 'C')
+				) STORED`,
+			`CREATE INDEX IF NOT EXISTS idx_recipes_search_vector ON recipes USING gin (search_vector)`,
+			`CREATE INDEX IF NOT EXISTS idx_recipes_name_trgm ON recipes USING gin (lower(name) gin_trgm_ops)`,
+		),
+		Down: execSQL(
+			`DROP INDEX IF EXISTS idx_recipes_name_trgm`,
+			`DROP INDEX IF EXISTS idx_recipes_search_vector`,
+			`ALTER TABLE recipes DROP COLUMN IF EXISTS search_vector`,
+			`DROP FUNCTION IF EXISTS recipe_search_text(text[])`,
+		),
+	},
+	{
+		Version: 13,
+		Name:    "create_recipe_embeddings",
+		Up: execSQL(
+			// Vectors are plain bytea so the schema does not depend on the
+			// pgvector extension; similarity is computed in memory
+			`CREATE TABLE IF NOT EXISTS recipe_embeddings (
+				recipe_id uuid NOT NULL,
+				embedder text NOT NULL,
+				dimensions integer NOT NULL,
+				vector bytea NOT NULL,
+				source_updated_at timestamptz NOT NULL,
+				created_at timestamptz,
+				updated_at timestamptz,
+				PRIMARY KEY (recipe_id, embedder),
+				CONSTRAINT fk_recipe_embeddings_recipe FOREIGN KEY (recipe_id) REFERENCES recipes (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_recipe_embeddings_updated_at ON recipe_embeddings (embedder, updated_at)`,
+		),
+		Down: execSQL(
+			`DROP TABLE IF EXISTS recipe_embeddings`,
+		),
+	},
+}
+
+// execSQL returns a migration step that runs statements in order
+func execSQL(statements ...string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, stmt := range statements {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// noop is the Down step of data-only migrations
+func noop(*gorm.DB) error {
+	return nil
+}
@@ -0,0 +1,131 @@
+// Package grpcerr maps the errors returned by gorm and Postgres to gRPC
+// status codes, so services can return database errors as they are and
+// callers still see NotFound, AlreadyExists or InvalidArgument. Callers get
+// a fixed message for each code; the database's own message, which names
+// constraints, columns and values, is only logged.
+package grpcerr
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Postgres error codes that describe a bad request rather than a fault
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+var pgCodes = map[string]codes.Code{
+	"23505": codes.AlreadyExists,     // unique_violation
+	"23503": codes.InvalidArgument,   // foreign_key_violation
+	"23502": codes.InvalidArgument,   // not_null_violation
+	"23514": codes.InvalidArgument,   // check_violation
+	"22P02": codes.InvalidArgument,   // invalid_text_representation, e.g. a malformed uuid
+	"22001": codes.InvalidArgument,   // string_data_right_truncation
+	"22003": codes.InvalidArgument,   // numeric_value_out_of_range
+	"22007": codes.InvalidArgument,   // invalid_datetime_format
+	"22008": codes.InvalidArgument,   // datetime_field_overflow
+	"40001": codes.Aborted,           // serialization_failure
+	"40P01": codes.Aborted,           // deadlock_detected
+	"57014": codes.DeadlineExceeded,  // query_canceled
+	"53300": codes.ResourceExhausted, // too_many_connections
+	"08006": codes.Unavailable,       // connection_failure
+	"57P01": codes.Unavailable,       // admin_shutdown
+}
+
+// messages are what callers see for database errors of each code
+var messages = map[codes.Code]string{
+	codes.NotFound:          "record not found",
+	codes.AlreadyExists:     "already exists",
+	codes.InvalidArgument:   "invalid value",
+	codes.Aborted:           "conflicting update, try again",
+	codes.Canceled:          "request canceled",
+	codes.DeadlineExceeded:  "database query timed out",
+	codes.ResourceExhausted: "database is busy, try again",
+	codes.Unavailable:       "database is unavailable",
+}
+
+// FromDB converts err to a gRPC status error with a fixed message. Errors
+// that already carry a status pass through unchanged, and nil stays nil.
+func FromDB(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(Code(err), Message(err))
+}
+
+// Message returns a message for err that is safe to show callers
+func Message(err error) string {
+	if s, ok := status.FromError(err); ok {
+		return s.Message()
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.Is(err, gorm.ErrForeignKeyViolated) || (errors.As(err, &pgErr) && pgErr.Code == "23503") {
+		return "referenced record does not exist"
+	}
+	if msg, ok := messages[Code(err)]; ok {
+		return msg
+	}
+	return "internal error"
+}
+
+// Code returns the gRPC code that best describes err
+func Code(err error) codes.Code {
+	if s, ok := status.FromError(err); ok {
+		return s.Code()
+	}
+
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return codes.NotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return codes.AlreadyExists
+	case errors.Is(err, gorm.ErrForeignKeyViolated),
+		errors.Is(err, gorm.ErrCheckConstraintViolated),
+		errors.Is(err, gorm.ErrInvalidData),
+		errors.Is(err, gorm.ErrInvalidValue),
+		errors.Is(err, gorm.ErrPrimaryKeyRequired),
+		errors.Is(err, gorm.ErrMissingWhereClause):
+		return codes.InvalidArgument
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.As(err, &pgErr):
+		if code, ok := pgCodes[pgErr.Code]; ok {
+			return code
+		}
+	}
+	return codes.Internal
+}
+
+// UnaryServerInterceptor applies FromDB to the errors of unary handlers.
+// Internal errors and database errors other than NotFound are logged in
+// full, since the caller only sees the code and a fixed message.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	return resp, convert(info.FullMethod, err)
+}
+
+// StreamServerInterceptor applies FromDB to the errors of streaming handlers
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return convert(info.FullMethod, handler(srv, ss))
+}
+
+func convert(method string, err error) error {
+	_, isStatus := status.FromError(err)
+	converted := FromDB(err)
+	if code := status.Code(converted); code == codes.Internal || (!isStatus && code != codes.NotFound) {
+		log.Printf("%s: %v", method, err)
+	}
+	return converted
+}
@@ -0,0 +1,150 @@
+// Package grpcserver runs the gRPC servers of the Go services with the
+// standard grpc.health.v1 service, optional server reflection and graceful
+// shutdown on SIGTERM.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"spiceroute/pkg/config"
+	"spiceroute/pkg/grpcerr"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"gorm.io/gorm"
+)
+
+const (
+	// pingInterval is how often the database is pinged to update health
+	pingInterval = 10 * time.Second
+	// pingTimeout bounds a single database ping
+	pingTimeout = 3 * time.Second
+	// drainTimeout is how long in-flight RPCs may run after SIGTERM before
+	// the server stops hard; it stays below Kubernetes' 30s grace period
+	drainTimeout = 25 * time.Second
+)
+
+// NewServer creates a gRPC server whose handlers report database errors
+// with matching status codes (see grpcerr). opts are applied after it.
+func NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(grpcerr.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(grpcerr.StreamServerInterceptor),
+	}, opts...)
+	return grpc.NewServer(opts...)
+}
+
+// Serve registers health checking (and reflection, when enabled) on srv,
+// then serves it on the configured listen address until SIGTERM or SIGINT.
+// When db is not nil the reported health follows database connectivity.
+// Serve returns once in-flight RPCs have drained.
+func Serve(srv *grpc.Server, cfg *config.Config, db *gorm.DB) error {
+	lis, err := net.Listen("tcp", cfg.ListenAddr())
+	if err != nil {
+		return err
+	}
+
+	// Services are registered by now, so health can cover each by name
+	var services []string
+	for name := range srv.GetServiceInfo() {
+		services = append(services, name)
+	}
+
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	if cfg.GRPC.Reflection {
+		reflection.Register(srv)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	if db != nil {
+		setStatus(hs, services, pingDatabase(ctx, db))
+		go watchDatabase(ctx, hs, services, db)
+	} else {
+		setStatus(hs, services, true)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		log.Println("Shutting down, draining in-flight requests...")
+
+		// Fail health checks first so load balancers stop routing to us
+		hs.Shutdown()
+
+		done := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(drainTimeout):
+			log.Println("Drain timed out, stopping")
+			srv.Stop()
+		}
+	}()
+
+	if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	<-stopped
+	return nil
+}
+
+// watchDatabase pings the database every pingInterval and reports the
+// result as the health of every service until ctx is done
+func watchDatabase(ctx context.Context, hs *health.Server, services []string, db *gorm.DB) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			setStatus(hs, services, pingDatabase(ctx, db))
+		}
+	}
+}
+
+// pingDatabase reports whether the database answers a ping
+func pingDatabase(ctx context.Context, db *gorm.DB) bool {
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Println("Health check failed to get database handle:", err)
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		log.Println("Health check failed to ping database:", err)
+		return false
+	}
+	return true
+}
+
+// setStatus sets the overall health and that of each service
+func setStatus(hs *health.Server, services []string, serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+
+	hs.SetServingStatus("", status)
+	for _, name := range services {
+		hs.SetServingStatus(name, status)
+	}
+}
@@ -0,0 +1,99 @@
+package ingredients
+
+import "strings"
+
+// Aisles lists store aisles in the order a shopper walks them
+var Aisles = []string{
+	"Produce",
+	"Meat & Seafood",
+	"Dairy & Eggs",
+	"Bakery",
+	"Grains & Pasta",
+	"Legumes",
+	"Spices & Seasonings",
+	"Oils & Condiments",
+	"Frozen",
+	"Other",
+}
+
+// aisleWords maps words of a canonical ingredient ID to their aisle
+var aisleWords = map[string]string{
+	// Produce
+	"onion": "Produce", "tomato": "Produce", "potato": "Produce", "garlic": "Produce",
+	"ginger": "Produce", "chilly": "Produce", "chili": "Produce", "chile": "Produce",
+	"pepper": "Produce", "capsicum": "Produce", "spinach": "Produce", "lettuce": "Produce",
+	"carrot": "Produce", "cucumber": "Produce", "cilantro": "Produce", "coriander": "Produce",
+	"mint": "Produce", "basil": "Produce", "lemon": "Produce", "lime": "Produce",
+	"cauliflower": "Produce", "cabbage": "Produce", "eggplant": "Produce", "brinjal": "Produce",
+	"okra": "Produce", "pea": "Produce", "bean": "Produce", "mushroom": "Produce",
+	"apple": "Produce", "banana": "Produce", "mango": "Produce", "avocado": "Produce",
+	"scallion": "Produce", "leek": "Produce", "celery": "Produce", "zucchini": "Produce",
+	"broccoli": "Produce", "kale": "Produce", "herb": "Produce", "leaf": "Produce",
+
+	// Meat & Seafood
+	"chicken": "Meat & Seafood", "mutton": "Meat & Seafood", "lamb": "Meat & Seafood",
+	"beef": "Meat & Seafood", "pork": "Meat & Seafood", "fish": "Meat & Seafood",
+	"prawn": "Meat & Seafood", "shrimp": "Meat & Seafood", "salmon": "Meat & Seafood",
+	"tuna": "Meat & Seafood", "turkey": "Meat & Seafood", "bacon": "Meat & Seafood",
+
+	// Dairy & Eggs
+	"milk": "Dairy & Eggs", "butter": "Dairy & Eggs", "ghee": "Dairy & Eggs",
+	"cheese": "Dairy & Eggs", "paneer": "Dairy & Eggs", "cream": "Dairy & Eggs",
+	"yogurt": "Dairy & Eggs", "curd": "Dairy & Eggs", "egg": "Dairy & Eggs",
+
+	// Bakery
+	"bread": "Bakery", "bun": "Bakery", "naan": "Bakery", "pita": "Bakery", "tortilla": "Bakery",
+
+	// Grains & Pasta
+	"rice": "Grains & Pasta", "flour": "Grains & Pasta", "atta": "Grains & Pasta",
+	"maida": "Grains & Pasta", "pasta": "Grains & Pasta", "noodle": "Grains & Pasta",
+	"semolina": "Grains & Pasta", "oat": "Grains & Pasta", "quinoa": "Grains & Pasta",
+	"couscous": "Grains & Pasta", "poha": "Grains & Pasta",
+
+	// Legumes
+	"dal": "Legumes", "lentil": "Legumes", "chickpea": "Legumes", "chana": "Legumes",
+	"rajma": "Legumes", "tofu": "Legumes",
+
+	// Spices & Seasonings
+	"salt": "Spices & Seasonings", "cumin": "Spices & Seasonings", "turmeric": "Spices & Seasonings",
+	"masala": "Spices & Seasonings", "cardamom": "Spices & Seasonings", "clove": "Spices & Seasonings",
+	"cinnamon": "Spices & Seasonings", "paprika": "Spices & Seasonings", "powder": "Spices & Seasonings",
+	"seed": "Spices & Seasonings", "peppercorn": "Spices & Seasonings", "spice": "Spices & Seasonings",
+	"oregano": "Spices & Seasonings", "thyme": "Spices & Seasonings", "asafoetida": "Spices & Seasonings",
+	"hing": "Spices & Seasonings",
+
+	// Oils & Condiments
+	"oil": "Oils & Condiments", "vinegar": "Oils & Condiments", "sauce": "Oils & Condiments",
+	"ketchup": "Oils & Condiments", "mustard": "Oils & Condiments", "honey": "Oils & Condiments",
+	"sugar": "Oils & Condiments", "jaggery": "Oils & Condiments", "paste": "Oils & Condiments",
+	"stock": "Oils & Condiments", "broth": "Oils & Condiments", "mayonnaise": "Oils & Condiments",
+
+	// Frozen
+	"frozen": "Frozen", "ice": "Frozen",
+}
+
+// Aisle returns the store aisle for an ingredient. The last matching word
+// wins, so "chicken-stock" lands with condiments rather than meat, while
+// "frozen-pea" is treated as frozen because the modifier is checked first.
+func Aisle(canonicalID string) string {
+	words := strings.Split(canonicalID, "-")
+	if len(words) > 1 && aisleWords[words[0]] == "Frozen" {
+		return "Frozen"
+	}
+	for i := len(words) - 1; i >= 0; i-- {
+		if aisle, ok := aisleWords[words[i]]; ok {
+			return aisle
+		}
+	}
+	return "Other"
+}
+
+// AisleRank orders aisles for display; unknown aisles sort last
+func AisleRank(aisle string) int {
+	for i, a := range Aisles {
+		if a == aisle {
+			return i
+		}
+	}
+	return len(Aisles)
+}
@@ -0,0 +1,188 @@
+package ingredients
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"spiceroute/pkg/models"
+)
+
+// vulgarFractions maps unicode fraction characters to their values
+var vulgarFractions = map[rune]float64{
+	'¼': 0.25, '½': 0.5, '¾': 0.75,
+	'⅓': 1.0 / 3, '⅔': 2.0 / 3,
+	'⅛': 0.125, '⅜': 0.375, '⅝': 0.625, '⅞': 0.875,
+}
+
+var (
+	// quantity matches "2", "1.5", "1/2", "1 1/2" and ranges such as "2-3"
+	quantity = regexp.MustCompile(`^(\d+\s+\d+/\d+|\d+/\d+|\d+(?:\.\d+)?)(?:\s*(?:-|to)\s*(\d+/\d+|\d+(?:\.\d+)?))?`)
+	// attachedUnit splits amounts written without a space, such as "500g"
+	attachedUnit  = regexp.MustCompile(`^(\d+(?:\.\d+)?)([a-zA-Z]+)\b`)
+	parenthetical = regexp.MustCompile(`\s*\(([^)]*)\)`)
+)
+
+// Parse converts a free-text ingredient line such as "2 cups basmati rice,
+// rinsed" or "500g chicken thighs (boneless)" into a structured ingredient.
+// Lines without a recognisable amount keep their text as the name.
+func Parse(line string) models.Ingredient {
+	ing := models.Ingredient{Raw: line}
+	text := strings.TrimSpace(line)
+
+	// Parenthesised and comma-separated trailers are preparation notes
+	var notes []string
+	for _, m := range parenthetical.FindAllStringSubmatch(text, -1) {
+		notes = append(notes, strings.TrimSpace(m[1]))
+	}
+	text = parenthetical.ReplaceAllString(text, "")
+	if i := strings.Index(text, ","); i >= 0 {
+		notes = append(notes, strings.TrimSpace(text[i+1:]))
+		text = strings.TrimSpace(text[:i])
+	}
+
+	text = expandFractions(text)
+	if m := attachedUnit.FindStringSubmatch(text); m != nil {
+		if _, ok := LookupUnit(m[2]); ok {
+			text = m[1] + " " + m[2] + text[len(m[0]):]
+		}
+	}
+
+	if m := quantity.FindStringSubmatch(text); m != nil {
+		ing.Quantity = parseAmount(m[1])
+		// Shop for the top of a range so the recipe never comes up short
+		if m[2] != "" {
+			ing.Quantity = parseAmount(m[2])
+		}
+		text = strings.TrimSpace(text[len(m[0]):])
+
+		if fields := strings.Fields(text); len(fields) > 0 {
+			unit, rest := fields[0], fields[1:]
+			if len(fields) > 1 {
+				if _, ok := LookupUnit(fields[0] + " " + fields[1]); ok {
+					unit, rest = fields[0]+" "+fields[1], fields[2:]
+				}
+			}
+			if u, ok := LookupUnit(unit); ok && len(rest) > 0 {
+				ing.Unit = u.Name
+				text = strings.Join(rest, " ")
+			}
+		}
+		text = strings.TrimPrefix(text, "of ")
+	}
+
+	for _, suffix := range []string{"to taste", "as needed", "as required"} {
+		if strings.HasSuffix(strings.ToLower(text), suffix) {
+			notes = append(notes, suffix)
+			text = strings.TrimSpace(text[:len(text)-len(suffix)])
+		}
+	}
+
+	ing.Name = strings.TrimSpace(text)
+	ing.Note = strings.Join(nonEmpty(notes), "; ")
+	ing.CanonicalID = CanonicalID(ing.Name)
+	return ing
+}
+
+// Format renders a structured ingredient back into a single line
+func Format(ing models.Ingredient) string {
+	var parts []string
+	if ing.Quantity > 0 {
+		parts = append(parts, strconv.FormatFloat(ing.Quantity, 'f', -1, 64))
+	}
+	if ing.Unit != "" {
+		parts = append(parts, ing.Unit)
+	}
+	parts = append(parts, ing.Name)
+	line := strings.Join(parts, " ")
+	if ing.Note != "" {
+		line += ", " + ing.Note
+	}
+	return line
+}
+
+// CanonicalID derives a stable identifier for an ingredient name so that
+// "Tomatoes" and "tomato" aggregate together: lowercase words with plurals
+// folded, joined by hyphens.
+func CanonicalID(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for i, w := range words {
+		words[i] = singular(w)
+	}
+	return strings.Join(words, "-")
+}
+
+// singular folds common English plural endings
+func singular(word string) string {
+	switch {
+	case len(word) <= 3:
+		return word
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// expandFractions rewrites unicode fractions as decimals, so "1½" becomes "1.5"
+func expandFractions(text string) string {
+	var b strings.Builder
+	runes := []rune(text)
+	for i, r := range runes {
+		value, ok := vulgarFractions[r]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		whole := 0.0
+		if i > 0 && unicode.IsDigit(runes[i-1]) {
+			// Fold into the preceding digits, e.g. "1½"
+			s := b.String()
+			j := len(s)
+			for j > 0 && s[j-1] >= '0' && s[j-1] <= '9' {
+				j--
+			}
+			whole, _ = strconv.ParseFloat(s[j:], 64)
+			b.Reset()
+			b.WriteString(s[:j])
+		}
+		b.WriteString(strconv.FormatFloat(whole+value, 'f', -1, 64))
+	}
+	return b.String()
+}
+
+// parseAmount parses "2", "1.5", "1/2" or "1 1/2"
+func parseAmount(s string) float64 {
+	total := 0.0
+	for _, part := range strings.Fields(s) {
+		if num, den, ok := strings.Cut(part, "/"); ok {
+			n, _ := strconv.ParseFloat(num, 64)
+			d, _ := strconv.ParseFloat(den, 64)
+			if d != 0 {
+				total += n / d
+			}
+			continue
+		}
+		v, _ := strconv.ParseFloat(part, 64)
+		total += v
+	}
+	return total
+}
+
+func nonEmpty(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package ingredients
+
+import (
+	"testing"
+
+	"spiceroute/pkg/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line string
+		want models.Ingredient
+	}{
+		{
+			line: "1 1/2 cups basmati rice, rinsed",
+			want: models.Ingredient{Quantity: 1.5, Unit: "cup", Name: "basmati rice", Note: "rinsed", CanonicalID: "basmati-rice"},
+		},
+		{
+			line: "2 c milk",
+			want: models.Ingredient{Quantity: 2, Unit: "cup", Name: "milk", CanonicalID: "milk"},
+		},
+		{
+			line: "1½ tbsp olive oil",
+			want: models.Ingredient{Quantity: 1.5, Unit: "tbsp", Name: "olive oil", CanonicalID: "olive-oil"},
+		},
+		{
+			line: "500g chicken thighs (boneless)",
+			want: models.Ingredient{Quantity: 500, Unit: "g", Name: "chicken thighs", Note: "boneless", CanonicalID: "chicken-thigh"},
+		},
+		{
+			line: "2-3 cloves garlic, minced",
+			want: models.Ingredient{Quantity: 3, Unit: "clove", Name: "garlic", Note: "minced", CanonicalID: "garlic"},
+		},
+		{
+			line: "1/2 fl oz vanilla extract",
+			want: models.Ingredient{Quantity: 0.5, Unit: "fl oz", Name: "vanilla extract", CanonicalID: "vanilla-extract"},
+		},
+		{
+			line: "3 handfuls spinach",
+			want: models.Ingredient{Quantity: 3, Name: "handfuls spinach", CanonicalID: "handful-spinach"},
+		},
+		{
+			line: "2 tomatoes",
+			want: models.Ingredient{Quantity: 2, Name: "tomatoes", CanonicalID: "tomato"},
+		},
+		{
+			line: "salt to taste",
+			want: models.Ingredient{Name: "salt", Note: "to taste", CanonicalID: "salt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			tt.want.Raw = tt.line
+			if got := Parse(tt.line); got != tt.want {
+				t.Errorf("Parse(%q) = %+v; want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalID(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Tomatoes", "tomato"},
+		{"cherry tomatoes", "cherry-tomato"},
+		{"Berries", "berry"},
+		{"peaches", "peach"},
+		{"Extra-virgin olive oil", "extra-virgin-olive-oil"},
+		{"hummus", "hummus"},
+		{"Swiss cheese", "swiss-cheese"},
+		{"peas", "pea"},
+	}
+
+	for _, tt := range tests {
+		if got := CanonicalID(tt.name); got != tt.want {
+			t.Errorf("CanonicalID(%q) = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package ingredients
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dimension is the physical quantity a unit measures
+type Dimension int
+
+const (
+	Count Dimension = iota
+	Mass
+	Volume
+)
+
+// Unit describes a recognised unit and its size in the base unit of its
+// dimension: grams for mass, millilitres for volume, and one item for counts.
+type Unit struct {
+	Name      string
+	Dimension Dimension
+	ToBase    float64
+}
+
+// units maps every accepted spelling to its unit. Cups and spoons use US
+// customary sizes.
+var units = map[string]Unit{}
+
+func init() {
+	register := func(u Unit, spellings ...string) {
+		units[u.Name] = u
+		for _, s := range spellings {
+			units[s] = u
+		}
+	}
+
+	register(Unit{"g", Mass, 1}, "gram", "grams", "gm", "gms", "gr")
+	register(Unit{"kg", Mass, 1000}, "kilogram", "kilograms", "kgs", "kilo", "kilos")
+	register(Unit{"mg", Mass, 0.001}, "milligram", "milligrams")
+	register(Unit{"oz", Mass, 28.3495}, "ounce", "ounces")
+	register(Unit{"lb", Mass, 453.592}, "lbs", "pound", "pounds")
+
+	register(Unit{"ml", Volume, 1}, "millilitre", "millilitres", "milliliter", "milliliters", "mls")
+	register(Unit{"l", Volume, 1000}, "litre", "litres", "liter", "liters", "ltr")
+	register(Unit{"tsp", Volume, 4.92892}, "teaspoon", "teaspoons", "tsps")
+	register(Unit{"tbsp", Volume, 14.7868}, "tablespoon", "tablespoons", "tbsps", "tbs", "tbl")
+	register(Unit{"cup", Volume, 236.588}, "cups", "c")
+	register(Unit{"fl oz", Volume, 29.5735}, "floz", "fluid ounce", "fluid ounces")
+	register(Unit{"pint", Volume, 473.176}, "pints", "pt")
+	register(Unit{"quart", Volume, 946.353}, "quarts", "qt")
+
+	for _, name := range []string{"clove", "can", "bunch", "piece", "pinch", "sprig", "slice", "stick", "packet", "head"} {
+		plural := name + "s"
+		if strings.HasSuffix(name, "ch") {
+			plural = name + "es"
+		}
+		register(Unit{name, Count, 1}, plural)
+	}
+}
+
+// densities lists approximate densities in grams per millilitre, keyed by
+// the last word of a canonical ingredient ID, so volume and mass amounts of
+// the same ingredient can be combined.
+var densities = map[string]float64{
+	"water":    1.0,
+	"stock":    1.0,
+	"broth":    1.0,
+	"milk":     1.03,
+	"cream":    1.0,
+	"yogurt":   1.03,
+	"curd":     1.03,
+	"oil":      0.92,
+	"ghee":     0.91,
+	"butter":   0.91,
+	"honey":    1.42,
+	"sugar":    0.85,
+	"salt":     1.2,
+	"flour":    0.53,
+	"atta":     0.53,
+	"rice":     0.85,
+	"lentil":   0.8,
+	"dal":      0.8,
+	"oat":      0.41,
+	"semolina": 0.7,
+	"paste":    1.1,
+	"sauce":    1.1,
+	"vinegar":  1.01,
+}
+
+// liquids are bought by volume, so ToBase keeps them in millilitres. They
+// are keyed like densities.
+var liquids = map[string]bool{
+	"water":   true,
+	"stock":   true,
+	"broth":   true,
+	"milk":    true,
+	"cream":   true,
+	"oil":     true,
+	"sauce":   true,
+	"vinegar": true,
+}
+
+// LookupUnit resolves a unit spelling such as "Tbsp" or "grams"
+func LookupUnit(name string) (Unit, bool) {
+	u, ok := units[strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))]
+	return u, ok
+}
+
+// NormalizeUnit returns the canonical spelling of a unit, or the input
+// lowercased when it is not recognised.
+func NormalizeUnit(name string) string {
+	if u, ok := LookupUnit(name); ok {
+		return u.Name
+	}
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Density returns the density in g/ml for an ingredient, if known
+func Density(canonicalID string) (float64, bool) {
+	d, ok := densities[lastWord(canonicalID)]
+	return d, ok
+}
+
+// IsLiquid reports whether an ingredient is bought by volume
+func IsLiquid(canonicalID string) bool {
+	return liquids[lastWord(canonicalID)]
+}
+
+func lastWord(canonicalID string) string {
+	words := strings.Split(canonicalID, "-")
+	return words[len(words)-1]
+}
+
+// Convert expresses quantity of unit from in unit to. Converting between
+// volume and mass uses the density of the ingredient identified by
+// canonicalID.
+func Convert(quantity float64, from, to, canonicalID string) (float64, error) {
+	src, ok := LookupUnit(from)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+	dst, ok := LookupUnit(to)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+
+	base := quantity * src.ToBase
+	if src.Dimension != dst.Dimension {
+		if src.Dimension == Count || dst.Dimension == Count {
+			return 0, fmt.Errorf("cannot convert %s to %s", src.Name, dst.Name)
+		}
+		density, ok := Density(canonicalID)
+		if !ok {
+			return 0, fmt.Errorf("no density known for %q", canonicalID)
+		}
+		if src.Dimension == Volume {
+			base *= density
+		} else {
+			base /= density
+		}
+	}
+
+	return base / dst.ToBase, nil
+}
+
+// ToBase converts a quantity to grams for mass units, millilitres for volume
+// units, or leaves counts as they are. Volumes of solids with a known
+// density, such as a cup of flour, are converted to grams so they combine
+// with weighed amounts; liquids stay in millilitres. Unrecognised units are
+// returned unchanged.
+func ToBase(quantity float64, unit, canonicalID string) (float64, string) {
+	u, ok := LookupUnit(unit)
+	if !ok {
+		return quantity, strings.ToLower(strings.TrimSpace(unit))
+	}
+
+	switch u.Dimension {
+	case Mass:
+		return quantity * u.ToBase, "g"
+	case Volume:
+		if density, ok := Density(canonicalID); ok && !IsLiquid(canonicalID) {
+			return quantity * u.ToBase * density, "g"
+		}
+		return quantity * u.ToBase, "ml"
+	default:
+		return quantity, u.Name
+	}
+}
+
+// Humanize rescales base amounts for display, turning 1500 g into 1.5 kg
+// and 2000 ml into 2 l. Other units are returned unchanged.
+func Humanize(quantity float64, unit string) (float64, string) {
+	switch {
+	case unit == "g" && quantity >= 1000:
+		return quantity / 1000, "kg"
+	case unit == "ml" && quantity >= 1000:
+		return quantity / 1000, "l"
+	}
+	return quantity, unit
+}
@@ -0,0 +1,67 @@
+package ingredients
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name        string
+		quantity    float64
+		from, to    string
+		canonicalID string
+		want        float64
+		ok          bool
+	}{
+		{name: "cups to millilitres", quantity: 2, from: "cups", to: "ml", want: 473.176, ok: true},
+		{name: "c for cup", quantity: 1, from: "c", to: "tbsp", want: 236.588 / 14.7868, ok: true},
+		{name: "kilograms to pounds", quantity: 1, from: "kg", to: "lb", want: 1000 / 453.592, ok: true},
+		{name: "volume to mass by density", quantity: 1, from: "cup", to: "g", canonicalID: "flour", want: 236.588 * 0.53, ok: true},
+		{name: "mass to volume by density", quantity: 103, from: "g", to: "ml", canonicalID: "whole-milk", want: 100, ok: true},
+		{name: "no density", quantity: 1, from: "cup", to: "g", canonicalID: "chicken-thigh"},
+		{name: "count to mass", quantity: 2, from: "cloves", to: "g", canonicalID: "garlic"},
+		{name: "unknown unit", quantity: 3, from: "handful", to: "g", canonicalID: "spinach"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(tt.quantity, tt.from, tt.to, tt.canonicalID)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Convert(%v, %q, %q, %q) error = %v; want ok %v", tt.quantity, tt.from, tt.to, tt.canonicalID, err, tt.ok)
+			}
+			if tt.ok && math.Abs(got-tt.want) > 1e-6*tt.want {
+				t.Errorf("Convert(%v, %q, %q, %q) = %v; want %v", tt.quantity, tt.from, tt.to, tt.canonicalID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToBase(t *testing.T) {
+	tests := []struct {
+		name        string
+		quantity    float64
+		unit        string
+		canonicalID string
+		want        float64
+		wantUnit    string
+	}{
+		{name: "mass", quantity: 2, unit: "lb", canonicalID: "chicken-thigh", want: 907.184, wantUnit: "g"},
+		{name: "volume without density", quantity: 1, unit: "tsp", canonicalID: "vanilla-extract", want: 4.92892, wantUnit: "ml"},
+		{name: "solid by volume", quantity: 1, unit: "cup", canonicalID: "flour", want: 236.588 * 0.53, wantUnit: "g"},
+		{name: "milk stays liquid", quantity: 2, unit: "cups", canonicalID: "milk", want: 473.176, wantUnit: "ml"},
+		{name: "stock stays liquid", quantity: 1, unit: "l", canonicalID: "chicken-stock", want: 1000, wantUnit: "ml"},
+		{name: "water stays liquid", quantity: 1, unit: "c", canonicalID: "water", want: 236.588, wantUnit: "ml"},
+		{name: "count", quantity: 3, unit: "cloves", canonicalID: "garlic", want: 3, wantUnit: "clove"},
+		{name: "unknown unit", quantity: 2, unit: "Handful", canonicalID: "spinach", want: 2, wantUnit: "handful"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unit := ToBase(tt.quantity, tt.unit, tt.canonicalID)
+			if unit != tt.wantUnit || math.Abs(got-tt.want) > 1e-6*tt.want {
+				t.Errorf("ToBase(%v, %q, %q) = %v %s; want %v %s", tt.quantity, tt.unit, tt.canonicalID, got, unit, tt.want, tt.wantUnit)
+			}
+		})
+	}
+}
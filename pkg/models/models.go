@@ -12,10 +12,13 @@ type User struct {
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	Email     string         `json:"email"`
+	Name      string         `json:"name"`
 
 	// Relations
 	Preferences []Preference `gorm:"foreignKey:UserID" json:"preferences,omitempty"`
 	Feedback    []Feedback   `gorm:"foreignKey:UserID" json:"feedback,omitempty"`
+	Plans       []MealPlan   `gorm:"foreignKey:UserID" json:"plans,omitempty"`
 }
 
 // Preference represents user dietary preferences
@@ -27,10 +30,17 @@ type Preference struct {
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Preferences
-	Cuisines   []string `gorm:"type:text[]" json:"cuisines"`
-	Allergies  []string `gorm:"type:text[]" json:"allergies"`
-	BudgetWeek float64  `json:"budget_week"`
-	Spicy      bool     `json:"spicy"`
+	Cuisines                []string          `gorm:"type:text[]" json:"cuisines"`
+	Allergies               []string          `gorm:"type:text[]" json:"allergies"`
+	BudgetWeek              float64           `json:"budget_week"`
+	Spicy                   bool              `json:"spicy"`
+	Diet                    string            `json:"diet"`
+	DislikedIngredients     []string          `gorm:"type:text[]" json:"disliked_ingredients"`
+	HouseholdSize           int32             `json:"household_size"`
+	Members                 []HouseholdMember `gorm:"type:jsonb;serializer:json" json:"members"`
+	SpiceLevel              int32             `json:"spice_level"`
+	MaxWeeknightPrepMinutes int32             `json:"max_weeknight_prep_minutes"`
+	SkillLevel              string            `json:"skill_level"`
 
 	// Relations
 	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
@@ -38,29 +48,83 @@ type Preference struct {
 
 // Recipe represents a recipe in the system
 type Recipe struct {
-	ID            string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
-	Name          string         `gorm:"not null" json:"name"`
-	Cuisine       string         `json:"cuisine"`
-	PrepMinutes   int32          `json:"prep_minutes"`
-	Calories      int32          `json:"calories"`
-	Ingredients   []string       `gorm:"type:text[]" json:"ingredients"`
-	Cost          float64        `json:"cost"`
-	ShelfLifeDays int32          `json:"shelf_life_days"`
-	Tags          []string       `gorm:"type:text[]" json:"tags"`
-	Nutrition     string         `json:"nutrition"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	ID             string          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	Name           string          `gorm:"not null" json:"name"`
+	Cuisine        string          `json:"cuisine"`
+	PrepMinutes    int32           `json:"prep_minutes"`
+	Calories       int32           `json:"calories"`
+	Ingredients    []string        `gorm:"type:text[]" json:"ingredients"`
+	Cost           float64         `json:"cost"`
+	ShelfLifeDays  int32           `json:"shelf_life_days"`
+	Tags           []string        `gorm:"type:text[]" json:"tags"`
+	Nutrition      string          `json:"nutrition"`
+	NutritionFacts *NutritionFacts `gorm:"type:jsonb;serializer:json" json:"nutrition_facts,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt  `gorm:"index" json:"-"`
 
 	// Relations
-	Feedback []Feedback `gorm:"foreignKey:DishID" json:"feedback,omitempty"`
+	StructuredIngredients []Ingredient `gorm:"foreignKey:RecipeID" json:"structured_ingredients,omitempty"`
+	Feedback              []Feedback   `gorm:"foreignKey:DishID" json:"feedback,omitempty"`
+}
+
+// Ingredient is one structured ingredient line of a recipe
+type Ingredient struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	RecipeID    string    `gorm:"type:uuid;not null;index" json:"recipe_id"`
+	Position    int32     `json:"position"`
+	Name        string    `gorm:"not null" json:"name"`
+	Quantity    float64   `json:"quantity"`
+	Unit        string    `json:"unit"`
+	Note        string    `json:"note"`
+	CanonicalID string    `gorm:"index" json:"canonical_id"`
+	Raw         string    `json:"raw"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// HouseholdMember is one person a household's plans are cooked for
+type HouseholdMember struct {
+	Name          string  `json:"name"`
+	DailyCalories float64 `json:"daily_calories"`
+}
+
+// NutritionFacts holds structured nutrition values for a recipe
+type NutritionFacts struct {
+	ProteinG   float64 `json:"protein_g"`
+	CarbsG     float64 `json:"carbs_g"`
+	FatG       float64 `json:"fat_g"`
+	FiberG     float64 `json:"fiber_g"`
+	SodiumMg   float64 `json:"sodium_mg"`
+	SugarG     float64 `json:"sugar_g"`
+	Servings   int32   `json:"servings"`
+	PerServing bool    `json:"per_serving"`
+}
+
+// PerServingFacts returns the facts scaled to a single serving
+func (n NutritionFacts) PerServingFacts() NutritionFacts {
+	if n.PerServing || n.Servings <= 1 {
+		n.PerServing = true
+		return n
+	}
+	div := float64(n.Servings)
+	return NutritionFacts{
+		ProteinG:   n.ProteinG / div,
+		CarbsG:     n.CarbsG / div,
+		FatG:       n.FatG / div,
+		FiberG:     n.FiberG / div,
+		SodiumMg:   n.SodiumMg / div,
+		SugarG:     n.SugarG / div,
+		Servings:   n.Servings,
+		PerServing: true,
+	}
 }
 
 // Feedback represents user feedback on dishes
 type Feedback struct {
 	ID              uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID          string         `gorm:"type:uuid;not null" json:"user_id"`
-	DishID          string         `gorm:"type:uuid;not null" json:"dish_id"`
+	UserID          string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	DishID          string         `gorm:"type:uuid;not null;index" json:"dish_id"`
 	Rating          int32          `json:"rating"`
 	Skipped         bool           `json:"skipped"`
 	SubstitutedWith string         `json:"substituted_with"`
@@ -75,11 +139,129 @@ type Feedback struct {
 	Recipe Recipe `gorm:"foreignKey:DishID" json:"recipe,omitempty"`
 }
 
+// MealPlan represents a generated meal plan for one user and week
+type MealPlan struct {
+	ID            string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID        string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	WeekStart     time.Time      `gorm:"type:date;index" json:"week_start"`
+	Days          int32          `json:"days"`
+	DailyCalories float64        `json:"daily_calories"`
+	BudgetWeek    float64        `json:"budget_week"`
+	DishIDs       []string       `gorm:"type:text[]" json:"dish_ids"`
+	CookDays      []string       `gorm:"type:text[]" json:"cook_days"`
+	ShoppingList  []string       `gorm:"type:text[]" json:"shopping_list"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Entries []PlanEntry `gorm:"foreignKey:PlanID" json:"entries,omitempty"`
+	User    User        `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// PlanEntry represents one dish scheduled on one day of a meal plan
+type PlanEntry struct {
+	ID       uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	PlanID   string `gorm:"type:uuid;not null;index" json:"plan_id"`
+	DayIndex int32  `json:"day_index"`
+	Position int32  `json:"position"`
+	DishID   string `gorm:"type:uuid;not null" json:"dish_id"`
+	Servings int32  `json:"servings"`
+
+	// Relations
+	Recipe Recipe `gorm:"foreignKey:DishID" json:"recipe,omitempty"`
+}
+
+// ShoppingList represents the groceries needed for a meal plan
+type ShoppingList struct {
+	ID        string         `gorm:"primaryKey;type:uuid;default:gen_random_uuid()" json:"id"`
+	UserID    string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	PlanID    *string        `gorm:"type:uuid;index" json:"plan_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Items []ShoppingItem `gorm:"foreignKey:ListID" json:"items,omitempty"`
+	Plan  *MealPlan      `gorm:"foreignKey:PlanID" json:"plan,omitempty"`
+}
+
+// ShoppingItem represents one line of a shopping list
+type ShoppingItem struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ListID      string    `gorm:"type:uuid;not null;index" json:"list_id"`
+	CanonicalID string    `json:"canonical_id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Quantity    float64   `json:"quantity"`
+	Unit        string    `json:"unit"`
+	Category    string    `json:"category"`
+	FromPantry  float64   `json:"from_pantry"`
+	Checked     bool      `json:"checked"`
+	Manual      bool      `json:"manual"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PantryItem represents an ingredient a user already has at home
+type PantryItem struct {
+	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	CanonicalID string         `gorm:"index" json:"canonical_id"`
+	Name        string         `gorm:"not null" json:"name"`
+	Quantity    float64        `json:"quantity"`
+	Unit        string         `json:"unit"`
+	ExpiresOn   *time.Time     `gorm:"type:date" json:"expires_on"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// Mood represents a user's cuisine picks for one week, layered over their
+// long-term preferences until it expires
+type Mood struct {
+	ID        uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	WeekStart time.Time      `gorm:"type:date" json:"week_start"`
+	Cuisines  []string       `gorm:"type:text[]" json:"cuisines"`
+	Exclusive bool           `json:"exclusive"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// RecipeEmbedding is a recipe's vector from one embedder, stored as
+// little-endian float32s. SourceUpdatedAt is the recipe's updated_at when it
+// was embedded, so changed recipes can be found and embedded again.
+type RecipeEmbedding struct {
+	RecipeID        string    `gorm:"type:uuid;primaryKey" json:"recipe_id"`
+	Embedder        string    `gorm:"primaryKey" json:"embedder"`
+	Dimensions      int32     `gorm:"not null" json:"dimensions"`
+	Vector          []byte    `gorm:"not null" json:"-"`
+	SourceUpdatedAt time.Time `gorm:"not null" json:"source_updated_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Relations
+	Recipe Recipe `gorm:"foreignKey:RecipeID" json:"recipe,omitempty"`
+}
+
 // TableName specifies the table name for Feedback
 func (Feedback) TableName() string {
 	return "feedback"
 }
 
+// TableName specifies the table name for Ingredient
+func (Ingredient) TableName() string {
+	return "recipe_ingredients"
+}
+
 // TableName specifies the table name for Preference
 func (Preference) TableName() string {
 	return "preferences"
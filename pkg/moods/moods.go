@@ -0,0 +1,83 @@
+// Package moods resolves the cuisines a user wants right now: their
+// long-term preferred cuisines blended with the mood set for this week.
+// Recipe listing, planning and the profile service all use it, so they
+// agree on the result.
+package moods
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"spiceroute/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+// WeekStart returns the Monday, at midnight UTC, of the week holding t
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// Current returns the user's mood in effect at now, or nil when there is
+// none. A mood is in effect from its week's Monday until it expires; when
+// several overlap the latest week wins.
+func Current(ctx context.Context, db *gorm.DB, userID string, now time.Time) (*models.Mood, error) {
+	var mood models.Mood
+	result := db.WithContext(ctx).
+		Where("user_id = ? AND week_start <= ? AND expires_at > ?", userID, now.UTC(), now.UTC()).
+		Order("week_start DESC").
+		First(&mood)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &mood, nil
+}
+
+// Effective loads the user's preferred cuisines and current mood and
+// blends them. A user without either gets no cuisines, meaning any.
+func Effective(ctx context.Context, db *gorm.DB, userID string, now time.Time) (cuisines, preferred []string, mood *models.Mood, err error) {
+	var preference models.Preference
+	result := db.WithContext(ctx).Where("user_id = ?", userID).First(&preference)
+	if result.Error != nil && !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil, nil, result.Error
+	}
+
+	mood, err = Current(ctx, db, userID, now)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return Blend(preference.Cuisines, mood), preference.Cuisines, mood, nil
+}
+
+// Blend combines preferred cuisines with a mood. The mood's cuisines come
+// first; an exclusive mood replaces the preferred ones entirely. Cuisines
+// are deduplicated case-insensitively, keeping the first spelling.
+func Blend(preferred []string, mood *models.Mood) []string {
+	var all []string
+	if mood != nil {
+		all = append(all, mood.Cuisines...)
+	}
+	if mood == nil || !mood.Exclusive {
+		all = append(all, preferred...)
+	}
+
+	seen := make(map[string]bool, len(all))
+	var blended []string
+	for _, cuisine := range all {
+		cuisine = strings.TrimSpace(cuisine)
+		key := strings.ToLower(cuisine)
+		if cuisine == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		blended = append(blended, cuisine)
+	}
+	return blended
+}
@@ -0,0 +1,107 @@
+package nutrition
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"spiceroute/pkg/models"
+)
+
+var (
+	// nutrientFirst matches labels such as "Protein: 12g" or "sodium 400 mg".
+	// The first group captures qualifiers such as "Saturated" so sub-lines
+	// of a US label do not overwrite the total above them.
+	nutrientFirst = regexp.MustCompile(`(?i)\b((?:saturated|trans|mono(?:unsaturated)?|poly(?:unsaturated)?|added)\s+)?(protein|carbohydrates?|carbs?|fat|fib(?:er|re)|sodium|sugars?)\s*[:=\-]?\s*(\d+(?:\.\d+)?)\s*(mg|g)?\b`)
+	// amountFirst matches labels such as "12g protein"
+	amountFirst = regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)\s*(mg|g)\s+(?:of\s+)?(protein|carbohydrates?|carbs?|fat|fib(?:er|re)|sodium|sugars?)\b`)
+	servings    = regexp.MustCompile(`(?i)\b(?:serves|servings?)\s*[:=]?\s*(\d+)\b|\b(\d+)\s+servings?\b`)
+	// wholeRecipe marks a label covering the whole recipe. A bare "total"
+	// does not, since per-serving labels read "Total Fat 10g".
+	wholeRecipe = regexp.MustCompile(`(?i)\b(?:per recipe|whole recipe)\b|\btotal\s*:`)
+)
+
+// Parse extracts structured nutrition facts from a free-text Recipe.Nutrition
+// label. It accepts JSON matching models.NutritionFacts as well as loose text
+// like "Protein 12g, Carbs: 40 g, Sodium 380mg, serves 4". The second result
+// is false when nothing recognizable was found.
+func Parse(text string) (models.NutritionFacts, bool) {
+	text = strings.TrimSpace(text)
+	facts := models.NutritionFacts{PerServing: true}
+	if text == "" {
+		return facts, false
+	}
+
+	if strings.HasPrefix(text, "{") {
+		if err := json.Unmarshal([]byte(text), &facts); err == nil {
+			return facts, true
+		}
+	}
+
+	found := false
+	for _, m := range nutrientFirst.FindAllStringSubmatch(text, -1) {
+		if m[1] != "" {
+			continue
+		}
+		found = set(&facts, m[2], m[3], m[4]) || found
+	}
+	for _, m := range amountFirst.FindAllStringSubmatch(text, -1) {
+		found = set(&facts, m[3], m[1], m[2]) || found
+	}
+	if !found {
+		return facts, false
+	}
+
+	if m := servings.FindStringSubmatch(text); m != nil {
+		count := m[1]
+		if count == "" {
+			count = m[2]
+		}
+		n, _ := strconv.Atoi(count)
+		facts.Servings = int32(n)
+	}
+	if wholeRecipe.MatchString(text) {
+		facts.PerServing = false
+	}
+
+	return facts, true
+}
+
+// set stores one parsed amount on facts, converting between grams and
+// milligrams where the label used the other unit.
+func set(facts *models.NutritionFacts, nutrient, amount, unit string) bool {
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return false
+	}
+	unit = strings.ToLower(unit)
+	nutrient = strings.ToLower(nutrient)
+
+	if strings.HasPrefix(nutrient, "sodium") {
+		if unit == "g" {
+			value *= 1000
+		}
+		facts.SodiumMg = value
+		return true
+	}
+
+	if unit == "mg" {
+		value /= 1000
+	}
+	switch {
+	case strings.HasPrefix(nutrient, "protein"):
+		facts.ProteinG = value
+	case strings.HasPrefix(nutrient, "carb"):
+		facts.CarbsG = value
+	case nutrient == "fat":
+		facts.FatG = value
+	case strings.HasPrefix(nutrient, "fib"):
+		facts.FiberG = value
+	case strings.HasPrefix(nutrient, "sugar"):
+		facts.SugarG = value
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package nutrition
+
+import (
+	"testing"
+
+	"spiceroute/pkg/models"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		label string
+		want  models.NutritionFacts
+		ok    bool
+	}{
+		{
+			name: "US nutrition label",
+			label: "Serving Size 1 cup, Servings 4, Calories 250, Total Fat 10g, Saturated Fat 3g, " +
+				"Trans Fat 0g, Cholesterol 30mg, Sodium 470mg, Total Carbohydrate 31g, Dietary Fiber 4g, " +
+				"Total Sugars 5g, Includes 2g Added Sugars, Protein 12g",
+			want: models.NutritionFacts{
+				ProteinG: 12, CarbsG: 31, FatG: 10, FiberG: 4, SodiumMg: 470, SugarG: 5,
+				Servings: 4, PerServing: true,
+			},
+			ok: true,
+		},
+		{
+			name:  "sub-lines before the total",
+			label: "Saturated Fat 3g, Polyunsaturated Fat 1g, Fat 10g, Added Sugars 2g, Sugars 6g",
+			want:  models.NutritionFacts{FatG: 10, SugarG: 6, PerServing: true},
+			ok:    true,
+		},
+		{
+			name:  "loose text",
+			label: "Protein 12g, Carbs: 40 g, Sodium 0.38g, serves 4",
+			want:  models.NutritionFacts{ProteinG: 12, CarbsG: 40, SodiumMg: 380, Servings: 4, PerServing: true},
+			ok:    true,
+		},
+		{
+			name:  "amount first",
+			label: "12g protein, 300 mg of sodium",
+			want:  models.NutritionFacts{ProteinG: 12, SodiumMg: 300, PerServing: true},
+			ok:    true,
+		},
+		{
+			name:  "per recipe",
+			label: "Protein 48g, fat 20g per recipe, 4 servings",
+			want:  models.NutritionFacts{ProteinG: 48, FatG: 20, Servings: 4},
+			ok:    true,
+		},
+		{
+			name:  "total qualifier",
+			label: "Total: protein 48g, carbs 160g",
+			want:  models.NutritionFacts{ProteinG: 48, CarbsG: 160},
+			ok:    true,
+		},
+		{
+			name:  "JSON",
+			label: `{"protein_g": 20, "servings": 2, "per_serving": true}`,
+			want:  models.NutritionFacts{ProteinG: 20, Servings: 2, PerServing: true},
+			ok:    true,
+		},
+		{
+			name:  "nothing recognizable",
+			label: "low in everything",
+			want:  models.NutritionFacts{PerServing: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.label)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("Parse(%q) = %+v, %v; want %+v, %v", tt.label, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,12 +24,22 @@ const (
 )
 
 type Preference struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Cuisines      []string               `protobuf:"bytes,2,rep,name=cuisines,proto3" json:"cuisines,omitempty"`
-	Allergies     []string               `protobuf:"bytes,3,rep,name=allergies,proto3" json:"allergies,omitempty"`
-	BudgetWeek    float64                `protobuf:"fixed64,4,opt,name=budget_week,json=budgetWeek,proto3" json:"budget_week,omitempty"`
-	Spicy         bool                   `protobuf:"varint,5,opt,name=spicy,proto3" json:"spicy,omitempty"`
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	UserId                  string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Cuisines                []string               `protobuf:"bytes,2,rep,name=cuisines,proto3" json:"cuisines,omitempty"`
+	Allergies               []string               `protobuf:"bytes,3,rep,name=allergies,proto3" json:"allergies,omitempty"`
+	BudgetWeek              float64                `protobuf:"fixed64,4,opt,name=budget_week,json=budgetWeek,proto3" json:"budget_week,omitempty"`
+	Spicy                   bool                   `protobuf:"varint,5,opt,name=spicy,proto3" json:"spicy,omitempty"` // kept for older clients; true when spice_level >= 3
+	Diet                    string                 `protobuf:"bytes,6,opt,name=diet,proto3" json:"diet,omitempty"`    // vegetarian, vegan, pescatarian, halal, kosher, keto; empty for none
+	DislikedIngredients     []string               `protobuf:"bytes,7,rep,name=disliked_ingredients,json=dislikedIngredients,proto3" json:"disliked_ingredients,omitempty"`
+	HouseholdSize           int32                  `protobuf:"varint,8,opt,name=household_size,json=householdSize,proto3" json:"household_size,omitempty"`
+	Members                 []*HouseholdMember     `protobuf:"bytes,9,rep,name=members,proto3" json:"members,omitempty"`
+	SpiceLevel              int32                  `protobuf:"varint,10,opt,name=spice_level,json=spiceLevel,proto3" json:"spice_level,omitempty"`                                            // 0 (none) to 5 (very hot)
+	MaxWeeknightPrepMinutes int32                  `protobuf:"varint,11,opt,name=max_weeknight_prep_minutes,json=maxWeeknightPrepMinutes,proto3" json:"max_weeknight_prep_minutes,omitempty"` // 0 for no limit
+	SkillLevel              string                 `protobuf:"bytes,12,opt,name=skill_level,json=skillLevel,proto3" json:"skill_level,omitempty"`                                             // beginner, intermediate, advanced
+	// UpsertPreference writes only these fields of an existing preference,
+	// e.g. paths: ["diet", "spice_level"]. Empty writes every field.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,13,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -98,28 +109,84 @@ func (x *Preference) GetSpicy() bool {
 	return false
 }
 
-type Mood struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CuisinesThisWeek []string               `protobuf:"bytes,2,rep,name=cuisines_this_week,json=cuisinesThisWeek,proto3" json:"cuisines_this_week,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *Preference) GetDiet() string {
+	if x != nil {
+		return x.Diet
+	}
+	return ""
 }
 
-func (x *Mood) Reset() {
-	*x = Mood{}
+func (x *Preference) GetDislikedIngredients() []string {
+	if x != nil {
+		return x.DislikedIngredients
+	}
+	return nil
+}
+
+func (x *Preference) GetHouseholdSize() int32 {
+	if x != nil {
+		return x.HouseholdSize
+	}
+	return 0
+}
+
+func (x *Preference) GetMembers() []*HouseholdMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Preference) GetSpiceLevel() int32 {
+	if x != nil {
+		return x.SpiceLevel
+	}
+	return 0
+}
+
+func (x *Preference) GetMaxWeeknightPrepMinutes() int32 {
+	if x != nil {
+		return x.MaxWeeknightPrepMinutes
+	}
+	return 0
+}
+
+func (x *Preference) GetSkillLevel() string {
+	if x != nil {
+		return x.SkillLevel
+	}
+	return ""
+}
+
+func (x *Preference) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type HouseholdMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DailyCalories float64                `protobuf:"fixed64,2,opt,name=daily_calories,json=dailyCalories,proto3" json:"daily_calories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HouseholdMember) Reset() {
+	*x = HouseholdMember{}
 	mi := &file_proto_spiceroute_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Mood) String() string {
+func (x *HouseholdMember) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Mood) ProtoMessage() {}
+func (*HouseholdMember) ProtoMessage() {}
 
-func (x *Mood) ProtoReflect() protoreflect.Message {
+func (x *HouseholdMember) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_spiceroute_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -131,53 +198,49 @@ func (x *Mood) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Mood.ProtoReflect.Descriptor instead.
-func (*Mood) Descriptor() ([]byte, []int) {
+// Deprecated: Use HouseholdMember.ProtoReflect.Descriptor instead.
+func (*HouseholdMember) Descriptor() ([]byte, []int) {
 	return file_proto_spiceroute_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Mood) GetUserId() string {
+func (x *HouseholdMember) GetName() string {
 	if x != nil {
-		return x.UserId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Mood) GetCuisinesThisWeek() []string {
+func (x *HouseholdMember) GetDailyCalories() float64 {
 	if x != nil {
-		return x.CuisinesThisWeek
+		return x.DailyCalories
 	}
-	return nil
+	return 0
 }
 
-type Dish struct {
+type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Cuisine       string                 `protobuf:"bytes,3,opt,name=cuisine,proto3" json:"cuisine,omitempty"`
-	PrepMinutes   int32                  `protobuf:"varint,4,opt,name=prep_minutes,json=prepMinutes,proto3" json:"prep_minutes,omitempty"`
-	Calories      int32                  `protobuf:"varint,5,opt,name=calories,proto3" json:"calories,omitempty"`
-	Ingredients   []string               `protobuf:"bytes,6,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
-	Cost          float64                `protobuf:"fixed64,7,opt,name=cost,proto3" json:"cost,omitempty"`
-	ShelfLifeDays int32                  `protobuf:"varint,8,opt,name=shelf_life_days,json=shelfLifeDays,proto3" json:"shelf_life_days,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // optional on create; defaults to a generated id
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Dish) Reset() {
-	*x = Dish{}
+func (x *User) Reset() {
+	*x = User{}
 	mi := &file_proto_spiceroute_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Dish) String() string {
+func (x *User) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Dish) ProtoMessage() {}
+func (*User) ProtoMessage() {}
 
-func (x *Dish) ProtoReflect() protoreflect.Message {
+func (x *User) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_spiceroute_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -189,92 +252,60 @@ func (x *Dish) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Dish.ProtoReflect.Descriptor instead.
-func (*Dish) Descriptor() ([]byte, []int) {
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
 	return file_proto_spiceroute_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Dish) GetId() string {
+func (x *User) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Dish) GetName() string {
+func (x *User) GetEmail() string {
 	if x != nil {
-		return x.Name
+		return x.Email
 	}
 	return ""
 }
 
-func (x *Dish) GetCuisine() string {
+func (x *User) GetName() string {
 	if x != nil {
-		return x.Cuisine
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Dish) GetPrepMinutes() int32 {
-	if x != nil {
-		return x.PrepMinutes
-	}
-	return 0
-}
-
-func (x *Dish) GetCalories() int32 {
-	if x != nil {
-		return x.Calories
-	}
-	return 0
-}
-
-func (x *Dish) GetIngredients() []string {
-	if x != nil {
-		return x.Ingredients
-	}
-	return nil
-}
-
-func (x *Dish) GetCost() float64 {
-	if x != nil {
-		return x.Cost
-	}
-	return 0
-}
-
-func (x *Dish) GetShelfLifeDays() int32 {
+func (x *User) GetCreatedAt() string {
 	if x != nil {
-		return x.ShelfLifeDays
+		return x.CreatedAt
 	}
-	return 0
+	return ""
 }
 
-type PlanRequest struct {
+type UserRef struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
-	Dishes        []*Dish                `protobuf:"bytes,3,rep,name=dishes,proto3" json:"dishes,omitempty"`
-	DailyCalories float64                `protobuf:"fixed64,4,opt,name=daily_calories,json=dailyCalories,proto3" json:"daily_calories,omitempty"`
-	BudgetWeek    float64                `protobuf:"fixed64,5,opt,name=budget_week,json=budgetWeek,proto3" json:"budget_week,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlanRequest) Reset() {
-	*x = PlanRequest{}
+func (x *UserRef) Reset() {
+	*x = UserRef{}
 	mi := &file_proto_spiceroute_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlanRequest) String() string {
+func (x *UserRef) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlanRequest) ProtoMessage() {}
+func (*UserRef) ProtoMessage() {}
 
-func (x *PlanRequest) ProtoReflect() protoreflect.Message {
+func (x *UserRef) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_spiceroute_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -286,69 +317,41 @@ func (x *PlanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlanRequest.ProtoReflect.Descriptor instead.
-func (*PlanRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserRef.ProtoReflect.Descriptor instead.
+func (*UserRef) Descriptor() ([]byte, []int) {
 	return file_proto_spiceroute_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *PlanRequest) GetUserId() string {
+func (x *UserRef) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *PlanRequest) GetDays() int32 {
-	if x != nil {
-		return x.Days
-	}
-	return 0
-}
-
-func (x *PlanRequest) GetDishes() []*Dish {
-	if x != nil {
-		return x.Dishes
-	}
-	return nil
-}
-
-func (x *PlanRequest) GetDailyCalories() float64 {
-	if x != nil {
-		return x.DailyCalories
-	}
-	return 0
-}
-
-func (x *PlanRequest) GetBudgetWeek() float64 {
-	if x != nil {
-		return x.BudgetWeek
-	}
-	return 0
-}
-
-type DailyMeals struct {
+type UserDataExport struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DayIndex      int32                  `protobuf:"varint,1,opt,name=day_index,json=dayIndex,proto3" json:"day_index,omitempty"`
-	DishIds       []string               `protobuf:"bytes,2,rep,name=dish_ids,json=dishIds,proto3" json:"dish_ids,omitempty"`
-	Servings      []int32                `protobuf:"varint,3,rep,packed,name=servings,proto3" json:"servings,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Document      []byte                 `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"` // JSON document with all of the user's data
+	ExportedAt    string                 `protobuf:"bytes,3,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DailyMeals) Reset() {
-	*x = DailyMeals{}
+func (x *UserDataExport) Reset() {
+	*x = UserDataExport{}
 	mi := &file_proto_spiceroute_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DailyMeals) String() string {
+func (x *UserDataExport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DailyMeals) ProtoMessage() {}
+func (*UserDataExport) ProtoMessage() {}
 
-func (x *DailyMeals) ProtoReflect() protoreflect.Message {
+func (x *UserDataExport) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_spiceroute_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -360,55 +363,57 @@ func (x *DailyMeals) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DailyMeals.ProtoReflect.Descriptor instead.
-func (*DailyMeals) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserDataExport.ProtoReflect.Descriptor instead.
+func (*UserDataExport) Descriptor() ([]byte, []int) {
 	return file_proto_spiceroute_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *DailyMeals) GetDayIndex() int32 {
+func (x *UserDataExport) GetUserId() string {
 	if x != nil {
-		return x.DayIndex
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-func (x *DailyMeals) GetDishIds() []string {
+func (x *UserDataExport) GetDocument() []byte {
 	if x != nil {
-		return x.DishIds
+		return x.Document
 	}
 	return nil
 }
 
-func (x *DailyMeals) GetServings() []int32 {
+func (x *UserDataExport) GetExportedAt() string {
 	if x != nil {
-		return x.Servings
+		return x.ExportedAt
 	}
-	return nil
+	return ""
 }
 
-type PlanResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Schedule      []*DailyMeals          `protobuf:"bytes,1,rep,name=schedule,proto3" json:"schedule,omitempty"`
-	CookDays      []string               `protobuf:"bytes,2,rep,name=cook_days,json=cookDays,proto3" json:"cook_days,omitempty"`
-	ShoppingList  []string               `protobuf:"bytes,3,rep,name=shopping_list,json=shoppingList,proto3" json:"shopping_list,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type Mood struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CuisinesThisWeek []string               `protobuf:"bytes,2,rep,name=cuisines_this_week,json=cuisinesThisWeek,proto3" json:"cuisines_this_week,omitempty"`
+	WeekStart        string                 `protobuf:"bytes,3,opt,name=week_start,json=weekStart,proto3" json:"week_start,omitempty"` // YYYY-MM-DD, moved back to its Monday; defaults to the current week
+	ExpiresAt        string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC 3339; defaults to the end of the week
+	Exclusive        bool                   `protobuf:"varint,5,opt,name=exclusive,proto3" json:"exclusive,omitempty"`                 // use only these cuisines rather than adding them to the preferred ones
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *PlanResponse) Reset() {
-	*x = PlanResponse{}
+func (x *Mood) Reset() {
+	*x = Mood{}
 	mi := &file_proto_spiceroute_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlanResponse) String() string {
+func (x *Mood) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlanResponse) ProtoMessage() {}
+func (*Mood) ProtoMessage() {}
 
-func (x *PlanResponse) ProtoReflect() protoreflect.Message {
+func (x *Mood) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_spiceroute_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -420,62 +425,70 @@ func (x *PlanResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlanResponse.ProtoReflect.Descriptor instead.
-func (*PlanResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Mood.ProtoReflect.Descriptor instead.
+func (*Mood) Descriptor() ([]byte, []int) {
 	return file_proto_spiceroute_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *PlanResponse) GetSchedule() []*DailyMeals {
+func (x *Mood) GetUserId() string {
 	if x != nil {
-		return x.Schedule
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-func (x *PlanResponse) GetCookDays() []string {
+func (x *Mood) GetCuisinesThisWeek() []string {
 	if x != nil {
-		return x.CookDays
+		return x.CuisinesThisWeek
 	}
 	return nil
 }
 
-func (x *PlanResponse) GetShoppingList() []string {
+func (x *Mood) GetWeekStart() string {
 	if x != nil {
-		return x.ShoppingList
+		return x.WeekStart
 	}
-	return nil
+	return ""
 }
 
-type Recipe struct {
+func (x *Mood) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *Mood) GetExclusive() bool {
+	if x != nil {
+		return x.Exclusive
+	}
+	return false
+}
+
+type EffectiveCuisines struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Cuisine       string                 `protobuf:"bytes,3,opt,name=cuisine,proto3" json:"cuisine,omitempty"`
-	PrepMinutes   int32                  `protobuf:"varint,4,opt,name=prep_minutes,json=prepMinutes,proto3" json:"prep_minutes,omitempty"`
-	Calories      int32                  `protobuf:"varint,5,opt,name=calories,proto3" json:"calories,omitempty"`
-	Ingredients   []string               `protobuf:"bytes,6,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
-	Cost          float64                `protobuf:"fixed64,7,opt,name=cost,proto3" json:"cost,omitempty"`
-	ShelfLifeDays int32                  `protobuf:"varint,8,opt,name=shelf_life_days,json=shelfLifeDays,proto3" json:"shelf_life_days,omitempty"`
-	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
-	Nutrition     string                 `protobuf:"bytes,10,opt,name=nutrition,proto3" json:"nutrition,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Cuisines      []string               `protobuf:"bytes,2,rep,name=cuisines,proto3" json:"cuisines,omitempty"`   // what recipe listing and planning use
+	Preferred     []string               `protobuf:"bytes,3,rep,name=preferred,proto3" json:"preferred,omitempty"` // the long-term Preference.cuisines
+	Mood          *Mood                  `protobuf:"bytes,4,opt,name=mood,proto3" json:"mood,omitempty"`           // the active mood, if any
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Recipe) Reset() {
-	*x = Recipe{}
+func (x *EffectiveCuisines) Reset() {
+	*x = EffectiveCuisines{}
 	mi := &file_proto_spiceroute_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Recipe) String() string {
+func (x *EffectiveCuisines) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Recipe) ProtoMessage() {}
+func (*EffectiveCuisines) ProtoMessage() {}
 
-func (x *Recipe) ProtoReflect() protoreflect.Message {
+func (x *EffectiveCuisines) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_spiceroute_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -487,103 +500,3464 @@ func (x *Recipe) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Recipe.ProtoReflect.Descriptor instead.
-func (*Recipe) Descriptor() ([]byte, []int) {
+// Deprecated: Use EffectiveCuisines.ProtoReflect.Descriptor instead.
+func (*EffectiveCuisines) Descriptor() ([]byte, []int) {
 	return file_proto_spiceroute_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Recipe) GetId() string {
+func (x *EffectiveCuisines) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *Recipe) GetName() string {
+func (x *EffectiveCuisines) GetCuisines() []string {
 	if x != nil {
-		return x.Name
+		return x.Cuisines
 	}
-	return ""
+	return nil
+}
+
+func (x *EffectiveCuisines) GetPreferred() []string {
+	if x != nil {
+		return x.Preferred
+	}
+	return nil
+}
+
+func (x *EffectiveCuisines) GetMood() *Mood {
+	if x != nil {
+		return x.Mood
+	}
+	return nil
+}
+
+type Dish struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Cuisine       string                 `protobuf:"bytes,3,opt,name=cuisine,proto3" json:"cuisine,omitempty"`
+	PrepMinutes   int32                  `protobuf:"varint,4,opt,name=prep_minutes,json=prepMinutes,proto3" json:"prep_minutes,omitempty"`
+	Calories      int32                  `protobuf:"varint,5,opt,name=calories,proto3" json:"calories,omitempty"`
+	Ingredients   []string               `protobuf:"bytes,6,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
+	Cost          float64                `protobuf:"fixed64,7,opt,name=cost,proto3" json:"cost,omitempty"`
+	ShelfLifeDays int32                  `protobuf:"varint,8,opt,name=shelf_life_days,json=shelfLifeDays,proto3" json:"shelf_life_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dish) Reset() {
+	*x = Dish{}
+	mi := &file_proto_spiceroute_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dish) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dish) ProtoMessage() {}
+
+func (x *Dish) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dish.ProtoReflect.Descriptor instead.
+func (*Dish) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Dish) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dish) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Dish) GetCuisine() string {
+	if x != nil {
+		return x.Cuisine
+	}
+	return ""
+}
+
+func (x *Dish) GetPrepMinutes() int32 {
+	if x != nil {
+		return x.PrepMinutes
+	}
+	return 0
+}
+
+func (x *Dish) GetCalories() int32 {
+	if x != nil {
+		return x.Calories
+	}
+	return 0
+}
+
+func (x *Dish) GetIngredients() []string {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *Dish) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *Dish) GetShelfLifeDays() int32 {
+	if x != nil {
+		return x.ShelfLifeDays
+	}
+	return 0
+}
+
+type PlanRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	UserId                string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Days                  int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	Dishes                []*Dish                `protobuf:"bytes,3,rep,name=dishes,proto3" json:"dishes,omitempty"`
+	DailyCalories         float64                `protobuf:"fixed64,4,opt,name=daily_calories,json=dailyCalories,proto3" json:"daily_calories,omitempty"`
+	BudgetWeek            float64                `protobuf:"fixed64,5,opt,name=budget_week,json=budgetWeek,proto3" json:"budget_week,omitempty"`
+	PrioritizeIngredients []string               `protobuf:"bytes,6,rep,name=prioritize_ingredients,json=prioritizeIngredients,proto3" json:"prioritize_ingredients,omitempty"` // canonical ids to use up first
+	PreferredCuisines     []string               `protobuf:"bytes,7,rep,name=preferred_cuisines,json=preferredCuisines,proto3" json:"preferred_cuisines,omitempty"`             // dishes of these cuisines are favoured
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *PlanRequest) Reset() {
+	*x = PlanRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanRequest) ProtoMessage() {}
+
+func (x *PlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanRequest.ProtoReflect.Descriptor instead.
+func (*PlanRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PlanRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlanRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *PlanRequest) GetDishes() []*Dish {
+	if x != nil {
+		return x.Dishes
+	}
+	return nil
+}
+
+func (x *PlanRequest) GetDailyCalories() float64 {
+	if x != nil {
+		return x.DailyCalories
+	}
+	return 0
+}
+
+func (x *PlanRequest) GetBudgetWeek() float64 {
+	if x != nil {
+		return x.BudgetWeek
+	}
+	return 0
+}
+
+func (x *PlanRequest) GetPrioritizeIngredients() []string {
+	if x != nil {
+		return x.PrioritizeIngredients
+	}
+	return nil
+}
+
+func (x *PlanRequest) GetPreferredCuisines() []string {
+	if x != nil {
+		return x.PreferredCuisines
+	}
+	return nil
+}
+
+type DailyMeals struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DayIndex      int32                  `protobuf:"varint,1,opt,name=day_index,json=dayIndex,proto3" json:"day_index,omitempty"`
+	DishIds       []string               `protobuf:"bytes,2,rep,name=dish_ids,json=dishIds,proto3" json:"dish_ids,omitempty"`
+	Servings      []int32                `protobuf:"varint,3,rep,packed,name=servings,proto3" json:"servings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyMeals) Reset() {
+	*x = DailyMeals{}
+	mi := &file_proto_spiceroute_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyMeals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyMeals) ProtoMessage() {}
+
+func (x *DailyMeals) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyMeals.ProtoReflect.Descriptor instead.
+func (*DailyMeals) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DailyMeals) GetDayIndex() int32 {
+	if x != nil {
+		return x.DayIndex
+	}
+	return 0
+}
+
+func (x *DailyMeals) GetDishIds() []string {
+	if x != nil {
+		return x.DishIds
+	}
+	return nil
+}
+
+func (x *DailyMeals) GetServings() []int32 {
+	if x != nil {
+		return x.Servings
+	}
+	return nil
+}
+
+type PlanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      []*DailyMeals          `protobuf:"bytes,1,rep,name=schedule,proto3" json:"schedule,omitempty"`
+	CookDays      []string               `protobuf:"bytes,2,rep,name=cook_days,json=cookDays,proto3" json:"cook_days,omitempty"`
+	ShoppingList  []string               `protobuf:"bytes,3,rep,name=shopping_list,json=shoppingList,proto3" json:"shopping_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanResponse) Reset() {
+	*x = PlanResponse{}
+	mi := &file_proto_spiceroute_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanResponse) ProtoMessage() {}
+
+func (x *PlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanResponse.ProtoReflect.Descriptor instead.
+func (*PlanResponse) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PlanResponse) GetSchedule() []*DailyMeals {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *PlanResponse) GetCookDays() []string {
+	if x != nil {
+		return x.CookDays
+	}
+	return nil
+}
+
+func (x *PlanResponse) GetShoppingList() []string {
+	if x != nil {
+		return x.ShoppingList
+	}
+	return nil
+}
+
+type MealPlan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WeekStart     string                 `protobuf:"bytes,3,opt,name=week_start,json=weekStart,proto3" json:"week_start,omitempty"` // YYYY-MM-DD, moved back to its Monday; defaults to the current week
+	Request       *PlanRequest           `protobuf:"bytes,4,opt,name=request,proto3" json:"request,omitempty"`
+	Plan          *PlanResponse          `protobuf:"bytes,5,opt,name=plan,proto3" json:"plan,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MealPlan) Reset() {
+	*x = MealPlan{}
+	mi := &file_proto_spiceroute_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MealPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MealPlan) ProtoMessage() {}
+
+func (x *MealPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MealPlan.ProtoReflect.Descriptor instead.
+func (*MealPlan) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MealPlan) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MealPlan) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MealPlan) GetWeekStart() string {
+	if x != nil {
+		return x.WeekStart
+	}
+	return ""
+}
+
+func (x *MealPlan) GetRequest() *PlanRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *MealPlan) GetPlan() *PlanResponse {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *MealPlan) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type PlanLookup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanLookup) Reset() {
+	*x = PlanLookup{}
+	mi := &file_proto_spiceroute_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanLookup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanLookup) ProtoMessage() {}
+
+func (x *PlanLookup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanLookup.ProtoReflect.Descriptor instead.
+func (*PlanLookup) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PlanLookup) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlanLookup) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+type PlanListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanListRequest) Reset() {
+	*x = PlanListRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanListRequest) ProtoMessage() {}
+
+func (x *PlanListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanListRequest.ProtoReflect.Descriptor instead.
+func (*PlanListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PlanListRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlanListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type MealPlanList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plans         []*MealPlan            `protobuf:"bytes,1,rep,name=plans,proto3" json:"plans,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MealPlanList) Reset() {
+	*x = MealPlanList{}
+	mi := &file_proto_spiceroute_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MealPlanList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MealPlanList) ProtoMessage() {}
+
+func (x *MealPlanList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MealPlanList.ProtoReflect.Descriptor instead.
+func (*MealPlanList) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MealPlanList) GetPlans() []*MealPlan {
+	if x != nil {
+		return x.Plans
+	}
+	return nil
+}
+
+type ShoppingItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit          string                 `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"` // store aisle
+	Checked       bool                   `protobuf:"varint,6,opt,name=checked,proto3" json:"checked,omitempty"`
+	Manual        bool                   `protobuf:"varint,7,opt,name=manual,proto3" json:"manual,omitempty"` // added or edited by the user rather than generated
+	CanonicalId   string                 `protobuf:"bytes,8,opt,name=canonical_id,json=canonicalId,proto3" json:"canonical_id,omitempty"`
+	FromPantry    float64                `protobuf:"fixed64,9,opt,name=from_pantry,json=fromPantry,proto3" json:"from_pantry,omitempty"` // amount already covered by pantry stock
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingItem) Reset() {
+	*x = ShoppingItem{}
+	mi := &file_proto_spiceroute_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingItem) ProtoMessage() {}
+
+func (x *ShoppingItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingItem.ProtoReflect.Descriptor instead.
+func (*ShoppingItem) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ShoppingItem) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ShoppingItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShoppingItem) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ShoppingItem) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ShoppingItem) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ShoppingItem) GetChecked() bool {
+	if x != nil {
+		return x.Checked
+	}
+	return false
+}
+
+func (x *ShoppingItem) GetManual() bool {
+	if x != nil {
+		return x.Manual
+	}
+	return false
+}
+
+func (x *ShoppingItem) GetCanonicalId() string {
+	if x != nil {
+		return x.CanonicalId
+	}
+	return ""
+}
+
+func (x *ShoppingItem) GetFromPantry() float64 {
+	if x != nil {
+		return x.FromPantry
+	}
+	return 0
+}
+
+type ShoppingAisle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Items         []*ShoppingItem        `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingAisle) Reset() {
+	*x = ShoppingAisle{}
+	mi := &file_proto_spiceroute_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingAisle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingAisle) ProtoMessage() {}
+
+func (x *ShoppingAisle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingAisle.ProtoReflect.Descriptor instead.
+func (*ShoppingAisle) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ShoppingAisle) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ShoppingAisle) GetItems() []*ShoppingItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ShoppingList struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId              string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PlanId              string                 `protobuf:"bytes,3,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	Aisles              []*ShoppingAisle       `protobuf:"bytes,4,rep,name=aisles,proto3" json:"aisles,omitempty"`
+	CreatedAt           string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiringPantryItems []*PantryItem          `protobuf:"bytes,6,rep,name=expiring_pantry_items,json=expiringPantryItems,proto3" json:"expiring_pantry_items,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ShoppingList) Reset() {
+	*x = ShoppingList{}
+	mi := &file_proto_spiceroute_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingList) ProtoMessage() {}
+
+func (x *ShoppingList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingList.ProtoReflect.Descriptor instead.
+func (*ShoppingList) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ShoppingList) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShoppingList) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShoppingList) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *ShoppingList) GetAisles() []*ShoppingAisle {
+	if x != nil {
+		return x.Aisles
+	}
+	return nil
+}
+
+func (x *ShoppingList) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ShoppingList) GetExpiringPantryItems() []*PantryItem {
+	if x != nil {
+		return x.ExpiringPantryItems
+	}
+	return nil
+}
+
+type GenerateShoppingListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateShoppingListRequest) Reset() {
+	*x = GenerateShoppingListRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateShoppingListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateShoppingListRequest) ProtoMessage() {}
+
+func (x *GenerateShoppingListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateShoppingListRequest.ProtoReflect.Descriptor instead.
+func (*GenerateShoppingListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GenerateShoppingListRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GenerateShoppingListRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+type ShoppingListLookup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ListId        string                 `protobuf:"bytes,2,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingListLookup) Reset() {
+	*x = ShoppingListLookup{}
+	mi := &file_proto_spiceroute_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingListLookup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingListLookup) ProtoMessage() {}
+
+func (x *ShoppingListLookup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingListLookup.ProtoReflect.Descriptor instead.
+func (*ShoppingListLookup) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ShoppingListLookup) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShoppingListLookup) GetListId() string {
+	if x != nil {
+		return x.ListId
+	}
+	return ""
+}
+
+type ShoppingListQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingListQuery) Reset() {
+	*x = ShoppingListQuery{}
+	mi := &file_proto_spiceroute_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingListQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingListQuery) ProtoMessage() {}
+
+func (x *ShoppingListQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingListQuery.ProtoReflect.Descriptor instead.
+func (*ShoppingListQuery) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ShoppingListQuery) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShoppingListQuery) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ShoppingLists struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lists         []*ShoppingList        `protobuf:"bytes,1,rep,name=lists,proto3" json:"lists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingLists) Reset() {
+	*x = ShoppingLists{}
+	mi := &file_proto_spiceroute_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingLists) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingLists) ProtoMessage() {}
+
+func (x *ShoppingLists) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingLists.ProtoReflect.Descriptor instead.
+func (*ShoppingLists) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ShoppingLists) GetLists() []*ShoppingList {
+	if x != nil {
+		return x.Lists
+	}
+	return nil
+}
+
+type ShoppingItemUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ListId        string                 `protobuf:"bytes,2,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	Item          *ShoppingItem          `protobuf:"bytes,3,opt,name=item,proto3" json:"item,omitempty"` // an item without an id is added to the list
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingItemUpdate) Reset() {
+	*x = ShoppingItemUpdate{}
+	mi := &file_proto_spiceroute_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingItemUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingItemUpdate) ProtoMessage() {}
+
+func (x *ShoppingItemUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingItemUpdate.ProtoReflect.Descriptor instead.
+func (*ShoppingItemUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ShoppingItemUpdate) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShoppingItemUpdate) GetListId() string {
+	if x != nil {
+		return x.ListId
+	}
+	return ""
+}
+
+func (x *ShoppingItemUpdate) GetItem() *ShoppingItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type ShoppingItemRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ListId        string                 `protobuf:"bytes,2,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	ItemId        uint64                 `protobuf:"varint,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingItemRef) Reset() {
+	*x = ShoppingItemRef{}
+	mi := &file_proto_spiceroute_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingItemRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingItemRef) ProtoMessage() {}
+
+func (x *ShoppingItemRef) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingItemRef.ProtoReflect.Descriptor instead.
+func (*ShoppingItemRef) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ShoppingItemRef) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShoppingItemRef) GetListId() string {
+	if x != nil {
+		return x.ListId
+	}
+	return ""
+}
+
+func (x *ShoppingItemRef) GetItemId() uint64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+type ShoppingItemCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ListId        string                 `protobuf:"bytes,2,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	ItemId        uint64                 `protobuf:"varint,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Checked       bool                   `protobuf:"varint,4,opt,name=checked,proto3" json:"checked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShoppingItemCheck) Reset() {
+	*x = ShoppingItemCheck{}
+	mi := &file_proto_spiceroute_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShoppingItemCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShoppingItemCheck) ProtoMessage() {}
+
+func (x *ShoppingItemCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShoppingItemCheck.ProtoReflect.Descriptor instead.
+func (*ShoppingItemCheck) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ShoppingItemCheck) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShoppingItemCheck) GetListId() string {
+	if x != nil {
+		return x.ListId
+	}
+	return ""
+}
+
+func (x *ShoppingItemCheck) GetItemId() uint64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *ShoppingItemCheck) GetChecked() bool {
+	if x != nil {
+		return x.Checked
+	}
+	return false
+}
+
+type PantryItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit          string                 `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	ExpiresOn     string                 `protobuf:"bytes,6,opt,name=expires_on,json=expiresOn,proto3" json:"expires_on,omitempty"` // YYYY-MM-DD, empty if it keeps
+	CanonicalId   string                 `protobuf:"bytes,7,opt,name=canonical_id,json=canonicalId,proto3" json:"canonical_id,omitempty"`
+	ExpiringSoon  bool                   `protobuf:"varint,8,opt,name=expiring_soon,json=expiringSoon,proto3" json:"expiring_soon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PantryItem) Reset() {
+	*x = PantryItem{}
+	mi := &file_proto_spiceroute_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PantryItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PantryItem) ProtoMessage() {}
+
+func (x *PantryItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PantryItem.ProtoReflect.Descriptor instead.
+func (*PantryItem) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PantryItem) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PantryItem) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PantryItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PantryItem) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PantryItem) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *PantryItem) GetExpiresOn() string {
+	if x != nil {
+		return x.ExpiresOn
+	}
+	return ""
+}
+
+func (x *PantryItem) GetCanonicalId() string {
+	if x != nil {
+		return x.CanonicalId
+	}
+	return ""
+}
+
+func (x *PantryItem) GetExpiringSoon() bool {
+	if x != nil {
+		return x.ExpiringSoon
+	}
+	return false
+}
+
+type PantryQuery struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	UserId             string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpiringWithinDays int32                  `protobuf:"varint,2,opt,name=expiring_within_days,json=expiringWithinDays,proto3" json:"expiring_within_days,omitempty"` // defaults to 3
+	ExpiringOnly       bool                   `protobuf:"varint,3,opt,name=expiring_only,json=expiringOnly,proto3" json:"expiring_only,omitempty"`                     // only items expiring from today on, not spoiled ones
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PantryQuery) Reset() {
+	*x = PantryQuery{}
+	mi := &file_proto_spiceroute_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PantryQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PantryQuery) ProtoMessage() {}
+
+func (x *PantryQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PantryQuery.ProtoReflect.Descriptor instead.
+func (*PantryQuery) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PantryQuery) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PantryQuery) GetExpiringWithinDays() int32 {
+	if x != nil {
+		return x.ExpiringWithinDays
+	}
+	return 0
+}
+
+func (x *PantryQuery) GetExpiringOnly() bool {
+	if x != nil {
+		return x.ExpiringOnly
+	}
+	return false
+}
+
+type PantryItems struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*PantryItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PantryItems) Reset() {
+	*x = PantryItems{}
+	mi := &file_proto_spiceroute_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PantryItems) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PantryItems) ProtoMessage() {}
+
+func (x *PantryItems) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PantryItems.ProtoReflect.Descriptor instead.
+func (*PantryItems) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PantryItems) GetItems() []*PantryItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type PantryItemRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemId        uint64                 `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PantryItemRef) Reset() {
+	*x = PantryItemRef{}
+	mi := &file_proto_spiceroute_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PantryItemRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PantryItemRef) ProtoMessage() {}
+
+func (x *PantryItemRef) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PantryItemRef.ProtoReflect.Descriptor instead.
+func (*PantryItemRef) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PantryItemRef) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PantryItemRef) GetItemId() uint64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+type Recipe struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Cuisine               string                 `protobuf:"bytes,3,opt,name=cuisine,proto3" json:"cuisine,omitempty"`
+	PrepMinutes           int32                  `protobuf:"varint,4,opt,name=prep_minutes,json=prepMinutes,proto3" json:"prep_minutes,omitempty"`
+	Calories              int32                  `protobuf:"varint,5,opt,name=calories,proto3" json:"calories,omitempty"`
+	Ingredients           []string               `protobuf:"bytes,6,rep,name=ingredients,proto3" json:"ingredients,omitempty"`
+	Cost                  float64                `protobuf:"fixed64,7,opt,name=cost,proto3" json:"cost,omitempty"`
+	ShelfLifeDays         int32                  `protobuf:"varint,8,opt,name=shelf_life_days,json=shelfLifeDays,proto3" json:"shelf_life_days,omitempty"`
+	Tags                  []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	Nutrition             string                 `protobuf:"bytes,10,opt,name=nutrition,proto3" json:"nutrition,omitempty"` // free-text label, kept for older clients
+	NutritionFacts        *NutritionFacts        `protobuf:"bytes,11,opt,name=nutrition_facts,json=nutritionFacts,proto3" json:"nutrition_facts,omitempty"`
+	StructuredIngredients []*Ingredient          `protobuf:"bytes,12,rep,name=structured_ingredients,json=structuredIngredients,proto3" json:"structured_ingredients,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Recipe) Reset() {
+	*x = Recipe{}
+	mi := &file_proto_spiceroute_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recipe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recipe) ProtoMessage() {}
+
+func (x *Recipe) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recipe.ProtoReflect.Descriptor instead.
+func (*Recipe) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Recipe) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Recipe) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Recipe) GetCuisine() string {
+	if x != nil {
+		return x.Cuisine
+	}
+	return ""
+}
+
+func (x *Recipe) GetPrepMinutes() int32 {
+	if x != nil {
+		return x.PrepMinutes
+	}
+	return 0
+}
+
+func (x *Recipe) GetCalories() int32 {
+	if x != nil {
+		return x.Calories
+	}
+	return 0
+}
+
+func (x *Recipe) GetIngredients() []string {
+	if x != nil {
+		return x.Ingredients
+	}
+	return nil
+}
+
+func (x *Recipe) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *Recipe) GetShelfLifeDays() int32 {
+	if x != nil {
+		return x.ShelfLifeDays
+	}
+	return 0
+}
+
+func (x *Recipe) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Recipe) GetNutrition() string {
+	if x != nil {
+		return x.Nutrition
+	}
+	return ""
+}
+
+func (x *Recipe) GetNutritionFacts() *NutritionFacts {
+	if x != nil {
+		return x.NutritionFacts
+	}
+	return nil
+}
+
+func (x *Recipe) GetStructuredIngredients() []*Ingredient {
+	if x != nil {
+		return x.StructuredIngredients
+	}
+	return nil
+}
+
+type Ingredient struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Unit          string                 `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	CanonicalId   string                 `protobuf:"bytes,5,opt,name=canonical_id,json=canonicalId,proto3" json:"canonical_id,omitempty"`
+	Raw           string                 `protobuf:"bytes,6,opt,name=raw,proto3" json:"raw,omitempty"` // original free-text line
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ingredient) Reset() {
+	*x = Ingredient{}
+	mi := &file_proto_spiceroute_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ingredient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ingredient) ProtoMessage() {}
+
+func (x *Ingredient) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ingredient.ProtoReflect.Descriptor instead.
+func (*Ingredient) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Ingredient) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Ingredient) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Ingredient) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Ingredient) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Ingredient) GetCanonicalId() string {
+	if x != nil {
+		return x.CanonicalId
+	}
+	return ""
+}
+
+func (x *Ingredient) GetRaw() string {
+	if x != nil {
+		return x.Raw
+	}
+	return ""
+}
+
+type RecipeImportRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"` // where the row came from, e.g. a file name
+	Row           int32                  `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`      // line or record number within source
+	Recipe        *Recipe                `protobuf:"bytes,3,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeImportRow) Reset() {
+	*x = RecipeImportRow{}
+	mi := &file_proto_spiceroute_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeImportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeImportRow) ProtoMessage() {}
+
+func (x *RecipeImportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeImportRow.ProtoReflect.Descriptor instead.
+func (*RecipeImportRow) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RecipeImportRow) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RecipeImportRow) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *RecipeImportRow) GetRecipe() *Recipe {
+	if x != nil {
+		return x.Recipe
+	}
+	return nil
+}
+
+type RecipeImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Row           int32                  `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeImportError) Reset() {
+	*x = RecipeImportError{}
+	mi := &file_proto_spiceroute_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeImportError) ProtoMessage() {}
+
+func (x *RecipeImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeImportError.ProtoReflect.Descriptor instead.
+func (*RecipeImportError) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RecipeImportError) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RecipeImportError) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *RecipeImportError) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecipeImportError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RecipeImportResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Received      int32                  `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Duplicates    int32                  `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"` // same normalized name and cuisine as a stored or earlier row
+	Failed        int32                  `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*RecipeImportError   `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeImportResult) Reset() {
+	*x = RecipeImportResult{}
+	mi := &file_proto_spiceroute_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeImportResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeImportResult) ProtoMessage() {}
+
+func (x *RecipeImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeImportResult.ProtoReflect.Descriptor instead.
+func (*RecipeImportResult) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RecipeImportResult) GetReceived() int32 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *RecipeImportResult) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *RecipeImportResult) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *RecipeImportResult) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *RecipeImportResult) GetErrors() []*RecipeImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type RecipeExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipeIds     []string               `protobuf:"bytes,1,rep,name=recipe_ids,json=recipeIds,proto3" json:"recipe_ids,omitempty"`
+	PlanId        string                 `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"` // export a plan's recipes as one cookbook instead
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // owner of plan_id
+	Format        string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`               // jsonld (default), markdown or html
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeExportRequest) Reset() {
+	*x = RecipeExportRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeExportRequest) ProtoMessage() {}
+
+func (x *RecipeExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeExportRequest.ProtoReflect.Descriptor instead.
+func (*RecipeExportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RecipeExportRequest) GetRecipeIds() []string {
+	if x != nil {
+		return x.RecipeIds
+	}
+	return nil
+}
+
+func (x *RecipeExportRequest) GetPlanId() string {
+	if x != nil {
+		return x.PlanId
+	}
+	return ""
+}
+
+func (x *RecipeExportRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecipeExportRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type RecipeExport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Document      []byte                 `protobuf:"bytes,4,opt,name=document,proto3" json:"document,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeExport) Reset() {
+	*x = RecipeExport{}
+	mi := &file_proto_spiceroute_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeExport) ProtoMessage() {}
+
+func (x *RecipeExport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeExport.ProtoReflect.Descriptor instead.
+func (*RecipeExport) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RecipeExport) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *RecipeExport) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *RecipeExport) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *RecipeExport) GetDocument() []byte {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+type RecipeSearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"` // words are matched as prefixes; misspelt names still match
+	Cuisines      []string               `protobuf:"bytes,2,rep,name=cuisines,proto3" json:"cuisines,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // default 20, at most 100
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeSearchRequest) Reset() {
+	*x = RecipeSearchRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeSearchRequest) ProtoMessage() {}
+
+func (x *RecipeSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeSearchRequest.ProtoReflect.Descriptor instead.
+func (*RecipeSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RecipeSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *RecipeSearchRequest) GetCuisines() []string {
+	if x != nil {
+		return x.Cuisines
+	}
+	return nil
+}
+
+func (x *RecipeSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RecipeSearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type RecipeSearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipe        *Recipe                `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Snippet       string                 `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"` // HTML-escaped, matches wrapped in <mark>
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeSearchHit) Reset() {
+	*x = RecipeSearchHit{}
+	mi := &file_proto_spiceroute_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeSearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeSearchHit) ProtoMessage() {}
+
+func (x *RecipeSearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeSearchHit.ProtoReflect.Descriptor instead.
+func (*RecipeSearchHit) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RecipeSearchHit) GetRecipe() *Recipe {
+	if x != nil {
+		return x.Recipe
+	}
+	return nil
+}
+
+func (x *RecipeSearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RecipeSearchHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type RecipeSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*RecipeSearchHit     `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeSearchResponse) Reset() {
+	*x = RecipeSearchResponse{}
+	mi := &file_proto_spiceroute_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeSearchResponse) ProtoMessage() {}
+
+func (x *RecipeSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeSearchResponse.ProtoReflect.Descriptor instead.
+func (*RecipeSearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RecipeSearchResponse) GetHits() []*RecipeSearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+type NutritionFacts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProteinG      float64                `protobuf:"fixed64,1,opt,name=protein_g,json=proteinG,proto3" json:"protein_g,omitempty"`
+	CarbsG        float64                `protobuf:"fixed64,2,opt,name=carbs_g,json=carbsG,proto3" json:"carbs_g,omitempty"`
+	FatG          float64                `protobuf:"fixed64,3,opt,name=fat_g,json=fatG,proto3" json:"fat_g,omitempty"`
+	FiberG        float64                `protobuf:"fixed64,4,opt,name=fiber_g,json=fiberG,proto3" json:"fiber_g,omitempty"`
+	SodiumMg      float64                `protobuf:"fixed64,5,opt,name=sodium_mg,json=sodiumMg,proto3" json:"sodium_mg,omitempty"`
+	SugarG        float64                `protobuf:"fixed64,6,opt,name=sugar_g,json=sugarG,proto3" json:"sugar_g,omitempty"`
+	Servings      int32                  `protobuf:"varint,7,opt,name=servings,proto3" json:"servings,omitempty"`
+	PerServing    bool                   `protobuf:"varint,8,opt,name=per_serving,json=perServing,proto3" json:"per_serving,omitempty"` // false when values cover the whole recipe
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NutritionFacts) Reset() {
+	*x = NutritionFacts{}
+	mi := &file_proto_spiceroute_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NutritionFacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NutritionFacts) ProtoMessage() {}
+
+func (x *NutritionFacts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NutritionFacts.ProtoReflect.Descriptor instead.
+func (*NutritionFacts) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *NutritionFacts) GetProteinG() float64 {
+	if x != nil {
+		return x.ProteinG
+	}
+	return 0
+}
+
+func (x *NutritionFacts) GetCarbsG() float64 {
+	if x != nil {
+		return x.CarbsG
+	}
+	return 0
+}
+
+func (x *NutritionFacts) GetFatG() float64 {
+	if x != nil {
+		return x.FatG
+	}
+	return 0
+}
+
+func (x *NutritionFacts) GetFiberG() float64 {
+	if x != nil {
+		return x.FiberG
+	}
+	return 0
+}
+
+func (x *NutritionFacts) GetSodiumMg() float64 {
+	if x != nil {
+		return x.SodiumMg
+	}
+	return 0
+}
+
+func (x *NutritionFacts) GetSugarG() float64 {
+	if x != nil {
+		return x.SugarG
+	}
+	return 0
+}
+
+func (x *NutritionFacts) GetServings() int32 {
+	if x != nil {
+		return x.Servings
+	}
+	return 0
+}
+
+func (x *NutritionFacts) GetPerServing() bool {
+	if x != nil {
+		return x.PerServing
+	}
+	return false
+}
+
+type RecipeID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeID) Reset() {
+	*x = RecipeID{}
+	mi := &file_proto_spiceroute_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeID) ProtoMessage() {}
+
+func (x *RecipeID) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeID.ProtoReflect.Descriptor instead.
+func (*RecipeID) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RecipeID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RecipeQuery struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Cuisines                []string               `protobuf:"bytes,1,rep,name=cuisines,proto3" json:"cuisines,omitempty"`
+	Spicy                   bool                   `protobuf:"varint,2,opt,name=spicy,proto3" json:"spicy,omitempty"`
+	PageSize                int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken               string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy                 string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"` // name, cost, prep_minutes, calories or created_at
+	Descending              bool                   `protobuf:"varint,6,opt,name=descending,proto3" json:"descending,omitempty"`
+	MaxPrepMinutes          int32                  `protobuf:"varint,7,opt,name=max_prep_minutes,json=maxPrepMinutes,proto3" json:"max_prep_minutes,omitempty"`
+	MinCalories             int32                  `protobuf:"varint,8,opt,name=min_calories,json=minCalories,proto3" json:"min_calories,omitempty"`
+	MaxCalories             int32                  `protobuf:"varint,9,opt,name=max_calories,json=maxCalories,proto3" json:"max_calories,omitempty"`
+	MaxCost                 float64                `protobuf:"fixed64,10,opt,name=max_cost,json=maxCost,proto3" json:"max_cost,omitempty"`
+	Tags                    []string               `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"` // recipes must carry every tag
+	ExcludeIngredients      []string               `protobuf:"bytes,12,rep,name=exclude_ingredients,json=excludeIngredients,proto3" json:"exclude_ingredients,omitempty"`
+	ExcludeAllergensForUser string                 `protobuf:"bytes,13,opt,name=exclude_allergens_for_user,json=excludeAllergensForUser,proto3" json:"exclude_allergens_for_user,omitempty"` // drop recipes carrying this user's allergens
+	CuisinesForUser         string                 `protobuf:"bytes,14,opt,name=cuisines_for_user,json=cuisinesForUser,proto3" json:"cuisines_for_user,omitempty"`                           // without cuisines, list this user's effective cuisines
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RecipeQuery) Reset() {
+	*x = RecipeQuery{}
+	mi := &file_proto_spiceroute_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeQuery) ProtoMessage() {}
+
+func (x *RecipeQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeQuery.ProtoReflect.Descriptor instead.
+func (*RecipeQuery) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RecipeQuery) GetCuisines() []string {
+	if x != nil {
+		return x.Cuisines
+	}
+	return nil
+}
+
+func (x *RecipeQuery) GetSpicy() bool {
+	if x != nil {
+		return x.Spicy
+	}
+	return false
+}
+
+func (x *RecipeQuery) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *RecipeQuery) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *RecipeQuery) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *RecipeQuery) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *RecipeQuery) GetMaxPrepMinutes() int32 {
+	if x != nil {
+		return x.MaxPrepMinutes
+	}
+	return 0
+}
+
+func (x *RecipeQuery) GetMinCalories() int32 {
+	if x != nil {
+		return x.MinCalories
+	}
+	return 0
+}
+
+func (x *RecipeQuery) GetMaxCalories() int32 {
+	if x != nil {
+		return x.MaxCalories
+	}
+	return 0
+}
+
+func (x *RecipeQuery) GetMaxCost() float64 {
+	if x != nil {
+		return x.MaxCost
+	}
+	return 0
+}
+
+func (x *RecipeQuery) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *RecipeQuery) GetExcludeIngredients() []string {
+	if x != nil {
+		return x.ExcludeIngredients
+	}
+	return nil
+}
+
+func (x *RecipeQuery) GetExcludeAllergensForUser() string {
+	if x != nil {
+		return x.ExcludeAllergensForUser
+	}
+	return ""
+}
+
+func (x *RecipeQuery) GetCuisinesForUser() string {
+	if x != nil {
+		return x.CuisinesForUser
+	}
+	return ""
+}
+
+type RecipeList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipes       []*Recipe              `protobuf:"bytes,1,rep,name=recipes,proto3" json:"recipes,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeList) Reset() {
+	*x = RecipeList{}
+	mi := &file_proto_spiceroute_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeList) ProtoMessage() {}
+
+func (x *RecipeList) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeList.ProtoReflect.Descriptor instead.
+func (*RecipeList) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RecipeList) GetRecipes() []*Recipe {
+	if x != nil {
+		return x.Recipes
+	}
+	return nil
+}
+
+func (x *RecipeList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *RecipeList) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type AllergenCheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RecipeIds     []string               `protobuf:"bytes,2,rep,name=recipe_ids,json=recipeIds,proto3" json:"recipe_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllergenCheckRequest) Reset() {
+	*x = AllergenCheckRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllergenCheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllergenCheckRequest) ProtoMessage() {}
+
+func (x *AllergenCheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllergenCheckRequest.ProtoReflect.Descriptor instead.
+func (*AllergenCheckRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AllergenCheckRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AllergenCheckRequest) GetRecipeIds() []string {
+	if x != nil {
+		return x.RecipeIds
+	}
+	return nil
+}
+
+type AllergenMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allergen      string                 `protobuf:"bytes,1,opt,name=allergen,proto3" json:"allergen,omitempty"`
+	Ingredient    string                 `protobuf:"bytes,2,opt,name=ingredient,proto3" json:"ingredient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllergenMatch) Reset() {
+	*x = AllergenMatch{}
+	mi := &file_proto_spiceroute_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllergenMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllergenMatch) ProtoMessage() {}
+
+func (x *AllergenMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllergenMatch.ProtoReflect.Descriptor instead.
+func (*AllergenMatch) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AllergenMatch) GetAllergen() string {
+	if x != nil {
+		return x.Allergen
+	}
+	return ""
+}
+
+func (x *AllergenMatch) GetIngredient() string {
+	if x != nil {
+		return x.Ingredient
+	}
+	return ""
+}
+
+type RecipeAllergens struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId      string                 `protobuf:"bytes,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	Safe          bool                   `protobuf:"varint,2,opt,name=safe,proto3" json:"safe,omitempty"`
+	Matches       []*AllergenMatch       `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipeAllergens) Reset() {
+	*x = RecipeAllergens{}
+	mi := &file_proto_spiceroute_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipeAllergens) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipeAllergens) ProtoMessage() {}
+
+func (x *RecipeAllergens) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipeAllergens.ProtoReflect.Descriptor instead.
+func (*RecipeAllergens) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RecipeAllergens) GetRecipeId() string {
+	if x != nil {
+		return x.RecipeId
+	}
+	return ""
+}
+
+func (x *RecipeAllergens) GetSafe() bool {
+	if x != nil {
+		return x.Safe
+	}
+	return false
+}
+
+func (x *RecipeAllergens) GetMatches() []*AllergenMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type AllergenReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allergies     []string               `protobuf:"bytes,1,rep,name=allergies,proto3" json:"allergies,omitempty"`
+	Recipes       []*RecipeAllergens     `protobuf:"bytes,2,rep,name=recipes,proto3" json:"recipes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllergenReport) Reset() {
+	*x = AllergenReport{}
+	mi := &file_proto_spiceroute_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllergenReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllergenReport) ProtoMessage() {}
+
+func (x *AllergenReport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllergenReport.ProtoReflect.Descriptor instead.
+func (*AllergenReport) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AllergenReport) GetAllergies() []string {
+	if x != nil {
+		return x.Allergies
+	}
+	return nil
+}
+
+func (x *AllergenReport) GetRecipes() []*RecipeAllergens {
+	if x != nil {
+		return x.Recipes
+	}
+	return nil
+}
+
+type Feedback struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DishId          string                 `protobuf:"bytes,2,opt,name=dish_id,json=dishId,proto3" json:"dish_id,omitempty"`
+	Rating          int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`
+	Skipped         bool                   `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	SubstitutedWith string                 `protobuf:"bytes,5,opt,name=substituted_with,json=substitutedWith,proto3" json:"substituted_with,omitempty"`
+	Comment         string                 `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
+	CookedAt        string                 `protobuf:"bytes,7,opt,name=cooked_at,json=cookedAt,proto3" json:"cooked_at,omitempty"`
+	Id              uint64                 `protobuf:"varint,8,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Feedback) Reset() {
+	*x = Feedback{}
+	mi := &file_proto_spiceroute_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feedback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feedback) ProtoMessage() {}
+
+func (x *Feedback) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feedback.ProtoReflect.Descriptor instead.
+func (*Feedback) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Feedback) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Feedback) GetDishId() string {
+	if x != nil {
+		return x.DishId
+	}
+	return ""
+}
+
+func (x *Feedback) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *Feedback) GetSkipped() bool {
+	if x != nil {
+		return x.Skipped
+	}
+	return false
+}
+
+func (x *Feedback) GetSubstitutedWith() string {
+	if x != nil {
+		return x.SubstitutedWith
+	}
+	return ""
+}
+
+func (x *Feedback) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Feedback) GetCookedAt() string {
+	if x != nil {
+		return x.CookedAt
+	}
+	return ""
+}
+
+func (x *Feedback) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type FeedbackBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Feedback            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	AllowPartial  bool                   `protobuf:"varint,2,opt,name=allow_partial,json=allowPartial,proto3" json:"allow_partial,omitempty"` // store valid entries even when others are rejected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackBatch) Reset() {
+	*x = FeedbackBatch{}
+	mi := &file_proto_spiceroute_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackBatch) ProtoMessage() {}
+
+func (x *FeedbackBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackBatch.ProtoReflect.Descriptor instead.
+func (*FeedbackBatch) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *FeedbackBatch) GetEntries() []*Feedback {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *FeedbackBatch) GetAllowPartial() bool {
+	if x != nil {
+		return x.AllowPartial
+	}
+	return false
+}
+
+type FeedbackEntryResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // position of the entry in the batch
+	Accepted      bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Reasons       []string               `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	Id            uint64                 `protobuf:"varint,4,opt,name=id,proto3" json:"id,omitempty"` // id of the stored feedback when accepted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackEntryResult) Reset() {
+	*x = FeedbackEntryResult{}
+	mi := &file_proto_spiceroute_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackEntryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackEntryResult) ProtoMessage() {}
+
+func (x *FeedbackEntryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackEntryResult.ProtoReflect.Descriptor instead.
+func (*FeedbackEntryResult) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *FeedbackEntryResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *FeedbackEntryResult) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *FeedbackEntryResult) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *FeedbackEntryResult) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type FeedbackBatchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*FeedbackEntryResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Accepted      int32                  `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      int32                  `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackBatchResult) Reset() {
+	*x = FeedbackBatchResult{}
+	mi := &file_proto_spiceroute_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackBatchResult) ProtoMessage() {}
+
+func (x *FeedbackBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackBatchResult.ProtoReflect.Descriptor instead.
+func (*FeedbackBatchResult) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *FeedbackBatchResult) GetResults() []*FeedbackEntryResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *FeedbackBatchResult) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *FeedbackBatchResult) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+type FeedbackQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RecipeId      string                 `protobuf:"bytes,2,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"` // RFC 3339, inclusive bound on cooked_at
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`     // RFC 3339, exclusive bound on cooked_at
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackQuery) Reset() {
+	*x = FeedbackQuery{}
+	mi := &file_proto_spiceroute_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackQuery) ProtoMessage() {}
+
+func (x *FeedbackQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackQuery.ProtoReflect.Descriptor instead.
+func (*FeedbackQuery) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *FeedbackQuery) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FeedbackQuery) GetRecipeId() string {
+	if x != nil {
+		return x.RecipeId
+	}
+	return ""
+}
+
+func (x *FeedbackQuery) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *FeedbackQuery) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *FeedbackQuery) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *FeedbackQuery) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type FeedbackPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*Feedback            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedbackPage) Reset() {
+	*x = FeedbackPage{}
+	mi := &file_proto_spiceroute_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedbackPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedbackPage) ProtoMessage() {}
+
+func (x *FeedbackPage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedbackPage.ProtoReflect.Descriptor instead.
+func (*FeedbackPage) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *FeedbackPage) GetEntries() []*Feedback {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *FeedbackPage) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RatingSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId      string                 `protobuf:"bytes,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingSummaryRequest) Reset() {
+	*x = RatingSummaryRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSummaryRequest) ProtoMessage() {}
+
+func (x *RatingSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingSummaryRequest.ProtoReflect.Descriptor instead.
+func (*RatingSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *RatingSummaryRequest) GetRecipeId() string {
+	if x != nil {
+		return x.RecipeId
+	}
+	return ""
+}
+
+func (x *RatingSummaryRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *RatingSummaryRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type RatingBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rating        int32                  `protobuf:"varint,1,opt,name=rating,proto3" json:"rating,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingBucket) Reset() {
+	*x = RatingBucket{}
+	mi := &file_proto_spiceroute_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingBucket) ProtoMessage() {}
+
+func (x *RatingBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingBucket.ProtoReflect.Descriptor instead.
+func (*RatingBucket) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RatingBucket) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *RatingBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SubstitutionCount struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SubstitutedWith string                 `protobuf:"bytes,1,opt,name=substituted_with,json=substitutedWith,proto3" json:"substituted_with,omitempty"`
+	Count           int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SubstitutionCount) Reset() {
+	*x = SubstitutionCount{}
+	mi := &file_proto_spiceroute_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubstitutionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubstitutionCount) ProtoMessage() {}
+
+func (x *SubstitutionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubstitutionCount.ProtoReflect.Descriptor instead.
+func (*SubstitutionCount) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SubstitutionCount) GetSubstitutedWith() string {
+	if x != nil {
+		return x.SubstitutedWith
+	}
+	return ""
 }
 
-func (x *Recipe) GetCuisine() string {
+func (x *SubstitutionCount) GetCount() int64 {
 	if x != nil {
-		return x.Cuisine
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-func (x *Recipe) GetPrepMinutes() int32 {
+type RatingSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RecipeId         string                 `protobuf:"bytes,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+	Total            int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // feedback entries, rated or skipped
+	Rated            int64                  `protobuf:"varint,3,opt,name=rated,proto3" json:"rated,omitempty"` // entries carrying a 1-5 rating
+	AverageRating    float64                `protobuf:"fixed64,4,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	Histogram        []*RatingBucket        `protobuf:"bytes,5,rep,name=histogram,proto3" json:"histogram,omitempty"` // one bucket per rating, 1 through 5
+	SkipRate         float64                `protobuf:"fixed64,6,opt,name=skip_rate,json=skipRate,proto3" json:"skip_rate,omitempty"`
+	TopSubstitutions []*SubstitutionCount   `protobuf:"bytes,7,rep,name=top_substitutions,json=topSubstitutions,proto3" json:"top_substitutions,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RatingSummary) Reset() {
+	*x = RatingSummary{}
+	mi := &file_proto_spiceroute_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSummary) ProtoMessage() {}
+
+func (x *RatingSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[56]
 	if x != nil {
-		return x.PrepMinutes
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Recipe) GetCalories() int32 {
+// Deprecated: Use RatingSummary.ProtoReflect.Descriptor instead.
+func (*RatingSummary) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *RatingSummary) GetRecipeId() string {
 	if x != nil {
-		return x.Calories
+		return x.RecipeId
 	}
-	return 0
+	return ""
 }
 
-func (x *Recipe) GetIngredients() []string {
+func (x *RatingSummary) GetTotal() int64 {
 	if x != nil {
-		return x.Ingredients
+		return x.Total
 	}
-	return nil
+	return 0
 }
 
-func (x *Recipe) GetCost() float64 {
+func (x *RatingSummary) GetRated() int64 {
 	if x != nil {
-		return x.Cost
+		return x.Rated
 	}
 	return 0
 }
 
-func (x *Recipe) GetShelfLifeDays() int32 {
+func (x *RatingSummary) GetAverageRating() float64 {
 	if x != nil {
-		return x.ShelfLifeDays
+		return x.AverageRating
 	}
 	return 0
 }
 
-func (x *Recipe) GetTags() []string {
+func (x *RatingSummary) GetHistogram() []*RatingBucket {
 	if x != nil {
-		return x.Tags
+		return x.Histogram
 	}
 	return nil
 }
 
-func (x *Recipe) GetNutrition() string {
+func (x *RatingSummary) GetSkipRate() float64 {
 	if x != nil {
-		return x.Nutrition
+		return x.SkipRate
 	}
-	return ""
+	return 0
 }
 
-type RecipeID struct {
+func (x *RatingSummary) GetTopSubstitutions() []*SubstitutionCount {
+	if x != nil {
+		return x.TopSubstitutions
+	}
+	return nil
+}
+
+type SemanticSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Cuisines      []string               `protobuf:"bytes,2,rep,name=cuisines,proto3" json:"cuisines,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                       // default 20, at most 100
+	MinSimilarity float64                `protobuf:"fixed64,4,opt,name=min_similarity,json=minSimilarity,proto3" json:"min_similarity,omitempty"` // drop matches less similar than this; 0 keeps all
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecipeID) Reset() {
-	*x = RecipeID{}
-	mi := &file_proto_spiceroute_proto_msgTypes[7]
+func (x *SemanticSearchRequest) Reset() {
+	*x = SemanticSearchRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecipeID) String() string {
+func (x *SemanticSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecipeID) ProtoMessage() {}
+func (*SemanticSearchRequest) ProtoMessage() {}
 
-func (x *RecipeID) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_spiceroute_proto_msgTypes[7]
+func (x *SemanticSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -594,41 +3968,63 @@ func (x *RecipeID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecipeID.ProtoReflect.Descriptor instead.
-func (*RecipeID) Descriptor() ([]byte, []int) {
-	return file_proto_spiceroute_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SemanticSearchRequest.ProtoReflect.Descriptor instead.
+func (*SemanticSearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *RecipeID) GetId() string {
+func (x *SemanticSearchRequest) GetQuery() string {
 	if x != nil {
-		return x.Id
+		return x.Query
 	}
 	return ""
 }
 
-type RecipeQuery struct {
+func (x *SemanticSearchRequest) GetCuisines() []string {
+	if x != nil {
+		return x.Cuisines
+	}
+	return nil
+}
+
+func (x *SemanticSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SemanticSearchRequest) GetMinSimilarity() float64 {
+	if x != nil {
+		return x.MinSimilarity
+	}
+	return 0
+}
+
+type RecommendationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cuisines      []string               `protobuf:"bytes,1,rep,name=cuisines,proto3" json:"cuisines,omitempty"`
-	Spicy         bool                   `protobuf:"varint,2,opt,name=spicy,proto3" json:"spicy,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`          // recommend from this user's ratings
+	RecipeIds     []string               `protobuf:"bytes,2,rep,name=recipe_ids,json=recipeIds,proto3" json:"recipe_ids,omitempty"` // recommend recipes like these
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                         // default 20, at most 100
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecipeQuery) Reset() {
-	*x = RecipeQuery{}
-	mi := &file_proto_spiceroute_proto_msgTypes[8]
+func (x *RecommendationRequest) Reset() {
+	*x = RecommendationRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecipeQuery) String() string {
+func (x *RecommendationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecipeQuery) ProtoMessage() {}
+func (*RecommendationRequest) ProtoMessage() {}
 
-func (x *RecipeQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_spiceroute_proto_msgTypes[8]
+func (x *RecommendationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -639,47 +4035,55 @@ func (x *RecipeQuery) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecipeQuery.ProtoReflect.Descriptor instead.
-func (*RecipeQuery) Descriptor() ([]byte, []int) {
-	return file_proto_spiceroute_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RecommendationRequest.ProtoReflect.Descriptor instead.
+func (*RecommendationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *RecipeQuery) GetCuisines() []string {
+func (x *RecommendationRequest) GetUserId() string {
 	if x != nil {
-		return x.Cuisines
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RecommendationRequest) GetRecipeIds() []string {
+	if x != nil {
+		return x.RecipeIds
 	}
 	return nil
 }
 
-func (x *RecipeQuery) GetSpicy() bool {
+func (x *RecommendationRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Spicy
+		return x.Limit
 	}
-	return false
+	return 0
 }
 
-type RecipeList struct {
+type SimilarRecipe struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Recipes       []*Recipe              `protobuf:"bytes,1,rep,name=recipes,proto3" json:"recipes,omitempty"`
+	Recipe        *Recipe                `protobuf:"bytes,1,opt,name=recipe,proto3" json:"recipe,omitempty"`
+	Similarity    float64                `protobuf:"fixed64,2,opt,name=similarity,proto3" json:"similarity,omitempty"` // cosine similarity, at most 1
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecipeList) Reset() {
-	*x = RecipeList{}
-	mi := &file_proto_spiceroute_proto_msgTypes[9]
+func (x *SimilarRecipe) Reset() {
+	*x = SimilarRecipe{}
+	mi := &file_proto_spiceroute_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecipeList) String() string {
+func (x *SimilarRecipe) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecipeList) ProtoMessage() {}
+func (*SimilarRecipe) ProtoMessage() {}
 
-func (x *RecipeList) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_spiceroute_proto_msgTypes[9]
+func (x *SimilarRecipe) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -690,46 +4094,48 @@ func (x *RecipeList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecipeList.ProtoReflect.Descriptor instead.
-func (*RecipeList) Descriptor() ([]byte, []int) {
-	return file_proto_spiceroute_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SimilarRecipe.ProtoReflect.Descriptor instead.
+func (*SimilarRecipe) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *RecipeList) GetRecipes() []*Recipe {
+func (x *SimilarRecipe) GetRecipe() *Recipe {
 	if x != nil {
-		return x.Recipes
+		return x.Recipe
 	}
 	return nil
 }
 
-type Feedback struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DishId          string                 `protobuf:"bytes,2,opt,name=dish_id,json=dishId,proto3" json:"dish_id,omitempty"`
-	Rating          int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`
-	Skipped         bool                   `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
-	SubstitutedWith string                 `protobuf:"bytes,5,opt,name=substituted_with,json=substitutedWith,proto3" json:"substituted_with,omitempty"`
-	Comment         string                 `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
-	CookedAt        string                 `protobuf:"bytes,7,opt,name=cooked_at,json=cookedAt,proto3" json:"cooked_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *SimilarRecipe) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
 }
 
-func (x *Feedback) Reset() {
-	*x = Feedback{}
-	mi := &file_proto_spiceroute_proto_msgTypes[10]
+type SimilarRecipes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipes       []*SimilarRecipe       `protobuf:"bytes,1,rep,name=recipes,proto3" json:"recipes,omitempty"`
+	Embedder      string                 `protobuf:"bytes,2,opt,name=embedder,proto3" json:"embedder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarRecipes) Reset() {
+	*x = SimilarRecipes{}
+	mi := &file_proto_spiceroute_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Feedback) String() string {
+func (x *SimilarRecipes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Feedback) ProtoMessage() {}
+func (*SimilarRecipes) ProtoMessage() {}
 
-func (x *Feedback) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_spiceroute_proto_msgTypes[10]
+func (x *SimilarRecipes) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -740,82 +4146,94 @@ func (x *Feedback) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Feedback.ProtoReflect.Descriptor instead.
-func (*Feedback) Descriptor() ([]byte, []int) {
-	return file_proto_spiceroute_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use SimilarRecipes.ProtoReflect.Descriptor instead.
+func (*SimilarRecipes) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *Feedback) GetUserId() string {
+func (x *SimilarRecipes) GetRecipes() []*SimilarRecipe {
 	if x != nil {
-		return x.UserId
+		return x.Recipes
 	}
-	return ""
+	return nil
 }
 
-func (x *Feedback) GetDishId() string {
+func (x *SimilarRecipes) GetEmbedder() string {
 	if x != nil {
-		return x.DishId
+		return x.Embedder
 	}
 	return ""
 }
 
-func (x *Feedback) GetRating() int32 {
-	if x != nil {
-		return x.Rating
-	}
-	return 0
+type ReindexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rebuild       bool                   `protobuf:"varint,1,opt,name=rebuild,proto3" json:"rebuild,omitempty"` // embed every recipe again, not only new and changed ones
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Feedback) GetSkipped() bool {
-	if x != nil {
-		return x.Skipped
-	}
-	return false
+func (x *ReindexRequest) Reset() {
+	*x = ReindexRequest{}
+	mi := &file_proto_spiceroute_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Feedback) GetSubstitutedWith() string {
-	if x != nil {
-		return x.SubstitutedWith
-	}
-	return ""
+func (x *ReindexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Feedback) GetComment() string {
+func (*ReindexRequest) ProtoMessage() {}
+
+func (x *ReindexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[61]
 	if x != nil {
-		return x.Comment
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Feedback) GetCookedAt() string {
+// Deprecated: Use ReindexRequest.ProtoReflect.Descriptor instead.
+func (*ReindexRequest) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ReindexRequest) GetRebuild() bool {
 	if x != nil {
-		return x.CookedAt
+		return x.Rebuild
 	}
-	return ""
+	return false
 }
 
-type FeedbackBatch struct {
+type VectorIndexStatus struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Entries       []*Feedback            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Embedder      string                 `protobuf:"bytes,1,opt,name=embedder,proto3" json:"embedder,omitempty"`
+	Dimensions    int32                  `protobuf:"varint,2,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Indexed       int64                  `protobuf:"varint,3,opt,name=indexed,proto3" json:"indexed,omitempty"`   // recipes in the index
+	Embedded      int64                  `protobuf:"varint,4,opt,name=embedded,proto3" json:"embedded,omitempty"` // recipes embedded by this call
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FeedbackBatch) Reset() {
-	*x = FeedbackBatch{}
-	mi := &file_proto_spiceroute_proto_msgTypes[11]
+func (x *VectorIndexStatus) Reset() {
+	*x = VectorIndexStatus{}
+	mi := &file_proto_spiceroute_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FeedbackBatch) String() string {
+func (x *VectorIndexStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FeedbackBatch) ProtoMessage() {}
+func (*VectorIndexStatus) ProtoMessage() {}
 
-func (x *FeedbackBatch) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_spiceroute_proto_msgTypes[11]
+func (x *VectorIndexStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_spiceroute_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -826,23 +4244,44 @@ func (x *FeedbackBatch) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FeedbackBatch.ProtoReflect.Descriptor instead.
-func (*FeedbackBatch) Descriptor() ([]byte, []int) {
-	return file_proto_spiceroute_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use VectorIndexStatus.ProtoReflect.Descriptor instead.
+func (*VectorIndexStatus) Descriptor() ([]byte, []int) {
+	return file_proto_spiceroute_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *FeedbackBatch) GetEntries() []*Feedback {
+func (x *VectorIndexStatus) GetEmbedder() string {
 	if x != nil {
-		return x.Entries
+		return x.Embedder
 	}
-	return nil
+	return ""
+}
+
+func (x *VectorIndexStatus) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
+}
+
+func (x *VectorIndexStatus) GetIndexed() int64 {
+	if x != nil {
+		return x.Indexed
+	}
+	return 0
+}
+
+func (x *VectorIndexStatus) GetEmbedded() int64 {
+	if x != nil {
+		return x.Embedded
+	}
+	return 0
 }
 
 var File_proto_spiceroute_proto protoreflect.FileDescriptor
 
 const file_proto_spiceroute_proto_rawDesc = "" +
 	"\n" +
-	"\x16proto/spiceroute.proto\x12\rspiceroute.v1\x1a\x1bgoogle/protobuf/empty.proto\"\x96\x01\n" +
+	"\x16proto/spiceroute.proto\x12\rspiceroute.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\"\xfa\x03\n" +
 	"\n" +
 	"Preference\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
@@ -850,10 +4289,48 @@ const file_proto_spiceroute_proto_rawDesc = "" +
 	"\tallergies\x18\x03 \x03(\tR\tallergies\x12\x1f\n" +
 	"\vbudget_week\x18\x04 \x01(\x01R\n" +
 	"budgetWeek\x12\x14\n" +
-	"\x05spicy\x18\x05 \x01(\bR\x05spicy\"M\n" +
+	"\x05spicy\x18\x05 \x01(\bR\x05spicy\x12\x12\n" +
+	"\x04diet\x18\x06 \x01(\tR\x04diet\x121\n" +
+	"\x14disliked_ingredients\x18\a \x03(\tR\x13dislikedIngredients\x12%\n" +
+	"\x0ehousehold_size\x18\b \x01(\x05R\rhouseholdSize\x128\n" +
+	"\amembers\x18\t \x03(\v2\x1e.spiceroute.v1.HouseholdMemberR\amembers\x12\x1f\n" +
+	"\vspice_level\x18\n" +
+	" \x01(\x05R\n" +
+	"spiceLevel\x12;\n" +
+	"\x1amax_weeknight_prep_minutes\x18\v \x01(\x05R\x17maxWeeknightPrepMinutes\x12\x1f\n" +
+	"\vskill_level\x18\f \x01(\tR\n" +
+	"skillLevel\x12;\n" +
+	"\vupdate_mask\x18\r \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"L\n" +
+	"\x0fHouseholdMember\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x0edaily_calories\x18\x02 \x01(\x01R\rdailyCalories\"_\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\"\n" +
+	"\aUserRef\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"f\n" +
+	"\x0eUserDataExport\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bdocument\x18\x02 \x01(\fR\bdocument\x12\x1f\n" +
+	"\vexported_at\x18\x03 \x01(\tR\n" +
+	"exportedAt\"\xa9\x01\n" +
 	"\x04Mood\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12,\n" +
-	"\x12cuisines_this_week\x18\x02 \x03(\tR\x10cuisinesThisWeek\"\xe1\x01\n" +
+	"\x12cuisines_this_week\x18\x02 \x03(\tR\x10cuisinesThisWeek\x12\x1d\n" +
+	"\n" +
+	"week_start\x18\x03 \x01(\tR\tweekStart\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\x12\x1c\n" +
+	"\texclusive\x18\x05 \x01(\bR\texclusive\"\x8f\x01\n" +
+	"\x11EffectiveCuisines\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bcuisines\x18\x02 \x03(\tR\bcuisines\x12\x1c\n" +
+	"\tpreferred\x18\x03 \x03(\tR\tpreferred\x12'\n" +
+	"\x04mood\x18\x04 \x01(\v2\x13.spiceroute.v1.MoodR\x04mood\"\xe1\x01\n" +
 	"\x04Dish\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
@@ -862,14 +4339,16 @@ const file_proto_spiceroute_proto_rawDesc = "" +
 	"\bcalories\x18\x05 \x01(\x05R\bcalories\x12 \n" +
 	"\vingredients\x18\x06 \x03(\tR\vingredients\x12\x12\n" +
 	"\x04cost\x18\a \x01(\x01R\x04cost\x12&\n" +
-	"\x0fshelf_life_days\x18\b \x01(\x05R\rshelfLifeDays\"\xaf\x01\n" +
+	"\x0fshelf_life_days\x18\b \x01(\x05R\rshelfLifeDays\"\x95\x02\n" +
 	"\vPlanRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04days\x18\x02 \x01(\x05R\x04days\x12+\n" +
 	"\x06dishes\x18\x03 \x03(\v2\x13.spiceroute.v1.DishR\x06dishes\x12%\n" +
 	"\x0edaily_calories\x18\x04 \x01(\x01R\rdailyCalories\x12\x1f\n" +
 	"\vbudget_week\x18\x05 \x01(\x01R\n" +
-	"budgetWeek\"`\n" +
+	"budgetWeek\x125\n" +
+	"\x16prioritize_ingredients\x18\x06 \x03(\tR\x15prioritizeIngredients\x12-\n" +
+	"\x12preferred_cuisines\x18\a \x03(\tR\x11preferredCuisines\"`\n" +
 	"\n" +
 	"DailyMeals\x12\x1b\n" +
 	"\tday_index\x18\x01 \x01(\x05R\bdayIndex\x12\x19\n" +
@@ -878,7 +4357,91 @@ const file_proto_spiceroute_proto_rawDesc = "" +
 	"\fPlanResponse\x125\n" +
 	"\bschedule\x18\x01 \x03(\v2\x19.spiceroute.v1.DailyMealsR\bschedule\x12\x1b\n" +
 	"\tcook_days\x18\x02 \x03(\tR\bcookDays\x12#\n" +
-	"\rshopping_list\x18\x03 \x03(\tR\fshoppingList\"\x95\x02\n" +
+	"\rshopping_list\x18\x03 \x03(\tR\fshoppingList\"\xd8\x01\n" +
+	"\bMealPlan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"week_start\x18\x03 \x01(\tR\tweekStart\x124\n" +
+	"\arequest\x18\x04 \x01(\v2\x1a.spiceroute.v1.PlanRequestR\arequest\x12/\n" +
+	"\x04plan\x18\x05 \x01(\v2\x1b.spiceroute.v1.PlanResponseR\x04plan\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\">\n" +
+	"\n" +
+	"PlanLookup\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\tR\x06planId\"@\n" +
+	"\x0fPlanListRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"=\n" +
+	"\fMealPlanList\x12-\n" +
+	"\x05plans\x18\x01 \x03(\v2\x17.spiceroute.v1.MealPlanR\x05plans\"\xf4\x01\n" +
+	"\fShoppingItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x01R\bquantity\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12\x18\n" +
+	"\achecked\x18\x06 \x01(\bR\achecked\x12\x16\n" +
+	"\x06manual\x18\a \x01(\bR\x06manual\x12!\n" +
+	"\fcanonical_id\x18\b \x01(\tR\vcanonicalId\x12\x1f\n" +
+	"\vfrom_pantry\x18\t \x01(\x01R\n" +
+	"fromPantry\"^\n" +
+	"\rShoppingAisle\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x121\n" +
+	"\x05items\x18\x02 \x03(\v2\x1b.spiceroute.v1.ShoppingItemR\x05items\"\xf4\x01\n" +
+	"\fShoppingList\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
+	"\aplan_id\x18\x03 \x01(\tR\x06planId\x124\n" +
+	"\x06aisles\x18\x04 \x03(\v2\x1c.spiceroute.v1.ShoppingAisleR\x06aisles\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12M\n" +
+	"\x15expiring_pantry_items\x18\x06 \x03(\v2\x19.spiceroute.v1.PantryItemR\x13expiringPantryItems\"O\n" +
+	"\x1bGenerateShoppingListRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\tR\x06planId\"F\n" +
+	"\x12ShoppingListLookup\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\alist_id\x18\x02 \x01(\tR\x06listId\"B\n" +
+	"\x11ShoppingListQuery\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"B\n" +
+	"\rShoppingLists\x121\n" +
+	"\x05lists\x18\x01 \x03(\v2\x1b.spiceroute.v1.ShoppingListR\x05lists\"w\n" +
+	"\x12ShoppingItemUpdate\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\alist_id\x18\x02 \x01(\tR\x06listId\x12/\n" +
+	"\x04item\x18\x03 \x01(\v2\x1b.spiceroute.v1.ShoppingItemR\x04item\"\\\n" +
+	"\x0fShoppingItemRef\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\alist_id\x18\x02 \x01(\tR\x06listId\x12\x17\n" +
+	"\aitem_id\x18\x03 \x01(\x04R\x06itemId\"x\n" +
+	"\x11ShoppingItemCheck\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\alist_id\x18\x02 \x01(\tR\x06listId\x12\x17\n" +
+	"\aitem_id\x18\x03 \x01(\x04R\x06itemId\x12\x18\n" +
+	"\achecked\x18\x04 \x01(\bR\achecked\"\xe0\x01\n" +
+	"\n" +
+	"PantryItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x01R\bquantity\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\x12\x1d\n" +
+	"\n" +
+	"expires_on\x18\x06 \x01(\tR\texpiresOn\x12!\n" +
+	"\fcanonical_id\x18\a \x01(\tR\vcanonicalId\x12#\n" +
+	"\rexpiring_soon\x18\b \x01(\bR\fexpiringSoon\"}\n" +
+	"\vPantryQuery\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x120\n" +
+	"\x14expiring_within_days\x18\x02 \x01(\x05R\x12expiringWithinDays\x12#\n" +
+	"\rexpiring_only\x18\x03 \x01(\bR\fexpiringOnly\">\n" +
+	"\vPantryItems\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.spiceroute.v1.PantryItemR\x05items\"A\n" +
+	"\rPantryItemRef\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\x04R\x06itemId\"\xaf\x03\n" +
 	"\x06Recipe\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
@@ -890,15 +4453,109 @@ const file_proto_spiceroute_proto_rawDesc = "" +
 	"\x0fshelf_life_days\x18\b \x01(\x05R\rshelfLifeDays\x12\x12\n" +
 	"\x04tags\x18\t \x03(\tR\x04tags\x12\x1c\n" +
 	"\tnutrition\x18\n" +
-	" \x01(\tR\tnutrition\"\x1a\n" +
+	" \x01(\tR\tnutrition\x12F\n" +
+	"\x0fnutrition_facts\x18\v \x01(\v2\x1d.spiceroute.v1.NutritionFactsR\x0enutritionFacts\x12P\n" +
+	"\x16structured_ingredients\x18\f \x03(\v2\x19.spiceroute.v1.IngredientR\x15structuredIngredients\"\x99\x01\n" +
+	"\n" +
+	"Ingredient\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\x12!\n" +
+	"\fcanonical_id\x18\x05 \x01(\tR\vcanonicalId\x12\x10\n" +
+	"\x03raw\x18\x06 \x01(\tR\x03raw\"j\n" +
+	"\x0fRecipeImportRow\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x10\n" +
+	"\x03row\x18\x02 \x01(\x05R\x03row\x12-\n" +
+	"\x06recipe\x18\x03 \x01(\v2\x15.spiceroute.v1.RecipeR\x06recipe\"g\n" +
+	"\x11RecipeImportError\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x10\n" +
+	"\x03row\x18\x02 \x01(\x05R\x03row\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xbc\x01\n" +
+	"\x12RecipeImportResult\x12\x1a\n" +
+	"\breceived\x18\x01 \x01(\x05R\breceived\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x05R\n" +
+	"duplicates\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x128\n" +
+	"\x06errors\x18\x05 \x03(\v2 .spiceroute.v1.RecipeImportErrorR\x06errors\"~\n" +
+	"\x13RecipeExportRequest\x12\x1d\n" +
+	"\n" +
+	"recipe_ids\x18\x01 \x03(\tR\trecipeIds\x12\x17\n" +
+	"\aplan_id\x18\x02 \x01(\tR\x06planId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\"\x81\x01\n" +
+	"\fRecipeExport\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x1a\n" +
+	"\bdocument\x18\x04 \x01(\fR\bdocument\"u\n" +
+	"\x13RecipeSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bcuisines\x18\x02 \x03(\tR\bcuisines\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"p\n" +
+	"\x0fRecipeSearchHit\x12-\n" +
+	"\x06recipe\x18\x01 \x01(\v2\x15.spiceroute.v1.RecipeR\x06recipe\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x18\n" +
+	"\asnippet\x18\x03 \x01(\tR\asnippet\"J\n" +
+	"\x14RecipeSearchResponse\x122\n" +
+	"\x04hits\x18\x01 \x03(\v2\x1e.spiceroute.v1.RecipeSearchHitR\x04hits\"\xe7\x01\n" +
+	"\x0eNutritionFacts\x12\x1b\n" +
+	"\tprotein_g\x18\x01 \x01(\x01R\bproteinG\x12\x17\n" +
+	"\acarbs_g\x18\x02 \x01(\x01R\x06carbsG\x12\x13\n" +
+	"\x05fat_g\x18\x03 \x01(\x01R\x04fatG\x12\x17\n" +
+	"\afiber_g\x18\x04 \x01(\x01R\x06fiberG\x12\x1b\n" +
+	"\tsodium_mg\x18\x05 \x01(\x01R\bsodiumMg\x12\x17\n" +
+	"\asugar_g\x18\x06 \x01(\x01R\x06sugarG\x12\x1a\n" +
+	"\bservings\x18\a \x01(\x05R\bservings\x12\x1f\n" +
+	"\vper_serving\x18\b \x01(\bR\n" +
+	"perServing\"\x1a\n" +
 	"\bRecipeID\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xef\x03\n" +
 	"\vRecipeQuery\x12\x1a\n" +
 	"\bcuisines\x18\x01 \x03(\tR\bcuisines\x12\x14\n" +
-	"\x05spicy\x18\x02 \x01(\bR\x05spicy\"=\n" +
+	"\x05spicy\x18\x02 \x01(\bR\x05spicy\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x05 \x01(\tR\aorderBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x06 \x01(\bR\n" +
+	"descending\x12(\n" +
+	"\x10max_prep_minutes\x18\a \x01(\x05R\x0emaxPrepMinutes\x12!\n" +
+	"\fmin_calories\x18\b \x01(\x05R\vminCalories\x12!\n" +
+	"\fmax_calories\x18\t \x01(\x05R\vmaxCalories\x12\x19\n" +
+	"\bmax_cost\x18\n" +
+	" \x01(\x01R\amaxCost\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags\x12/\n" +
+	"\x13exclude_ingredients\x18\f \x03(\tR\x12excludeIngredients\x12;\n" +
+	"\x1aexclude_allergens_for_user\x18\r \x01(\tR\x17excludeAllergensForUser\x12*\n" +
+	"\x11cuisines_for_user\x18\x0e \x01(\tR\x0fcuisinesForUser\"\x86\x01\n" +
 	"\n" +
 	"RecipeList\x12/\n" +
-	"\arecipes\x18\x01 \x03(\v2\x15.spiceroute.v1.RecipeR\arecipes\"\xd0\x01\n" +
+	"\arecipes\x18\x01 \x03(\v2\x15.spiceroute.v1.RecipeR\arecipes\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x03R\n" +
+	"totalCount\"N\n" +
+	"\x14AllergenCheckRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"recipe_ids\x18\x02 \x03(\tR\trecipeIds\"K\n" +
+	"\rAllergenMatch\x12\x1a\n" +
+	"\ballergen\x18\x01 \x01(\tR\ballergen\x12\x1e\n" +
+	"\n" +
+	"ingredient\x18\x02 \x01(\tR\n" +
+	"ingredient\"z\n" +
+	"\x0fRecipeAllergens\x12\x1b\n" +
+	"\trecipe_id\x18\x01 \x01(\tR\brecipeId\x12\x12\n" +
+	"\x04safe\x18\x02 \x01(\bR\x04safe\x126\n" +
+	"\amatches\x18\x03 \x03(\v2\x1c.spiceroute.v1.AllergenMatchR\amatches\"h\n" +
+	"\x0eAllergenReport\x12\x1c\n" +
+	"\tallergies\x18\x01 \x03(\tR\tallergies\x128\n" +
+	"\arecipes\x18\x02 \x03(\v2\x1e.spiceroute.v1.RecipeAllergensR\arecipes\"\xe0\x01\n" +
 	"\bFeedback\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
 	"\adish_id\x18\x02 \x01(\tR\x06dishId\x12\x16\n" +
@@ -906,19 +4563,129 @@ const file_proto_spiceroute_proto_rawDesc = "" +
 	"\askipped\x18\x04 \x01(\bR\askipped\x12)\n" +
 	"\x10substituted_with\x18\x05 \x01(\tR\x0fsubstitutedWith\x12\x18\n" +
 	"\acomment\x18\x06 \x01(\tR\acomment\x12\x1b\n" +
-	"\tcooked_at\x18\a \x01(\tR\bcookedAt\"B\n" +
+	"\tcooked_at\x18\a \x01(\tR\bcookedAt\x12\x0e\n" +
+	"\x02id\x18\b \x01(\x04R\x02id\"g\n" +
 	"\rFeedbackBatch\x121\n" +
-	"\aentries\x18\x01 \x03(\v2\x17.spiceroute.v1.FeedbackR\aentries2\xa1\x01\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.spiceroute.v1.FeedbackR\aentries\x12#\n" +
+	"\rallow_partial\x18\x02 \x01(\bR\fallowPartial\"q\n" +
+	"\x13FeedbackEntryResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\x12\x18\n" +
+	"\areasons\x18\x03 \x03(\tR\areasons\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\x04R\x02id\"\x8b\x01\n" +
+	"\x13FeedbackBatchResult\x12<\n" +
+	"\aresults\x18\x01 \x03(\v2\".spiceroute.v1.FeedbackEntryResultR\aresults\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x03 \x01(\x05R\brejected\"\xa5\x01\n" +
+	"\rFeedbackQuery\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\trecipe_id\x18\x02 \x01(\tR\brecipeId\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"i\n" +
+	"\fFeedbackPage\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.spiceroute.v1.FeedbackR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"W\n" +
+	"\x14RatingSummaryRequest\x12\x1b\n" +
+	"\trecipe_id\x18\x01 \x01(\tR\brecipeId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\"<\n" +
+	"\fRatingBucket\x12\x16\n" +
+	"\x06rating\x18\x01 \x01(\x05R\x06rating\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"T\n" +
+	"\x11SubstitutionCount\x12)\n" +
+	"\x10substituted_with\x18\x01 \x01(\tR\x0fsubstitutedWith\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xa6\x02\n" +
+	"\rRatingSummary\x12\x1b\n" +
+	"\trecipe_id\x18\x01 \x01(\tR\brecipeId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05rated\x18\x03 \x01(\x03R\x05rated\x12%\n" +
+	"\x0eaverage_rating\x18\x04 \x01(\x01R\raverageRating\x129\n" +
+	"\thistogram\x18\x05 \x03(\v2\x1b.spiceroute.v1.RatingBucketR\thistogram\x12\x1b\n" +
+	"\tskip_rate\x18\x06 \x01(\x01R\bskipRate\x12M\n" +
+	"\x11top_substitutions\x18\a \x03(\v2 .spiceroute.v1.SubstitutionCountR\x10topSubstitutions\"\x86\x01\n" +
+	"\x15SemanticSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bcuisines\x18\x02 \x03(\tR\bcuisines\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12%\n" +
+	"\x0emin_similarity\x18\x04 \x01(\x01R\rminSimilarity\"e\n" +
+	"\x15RecommendationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"recipe_ids\x18\x02 \x03(\tR\trecipeIds\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"^\n" +
+	"\rSimilarRecipe\x12-\n" +
+	"\x06recipe\x18\x01 \x01(\v2\x15.spiceroute.v1.RecipeR\x06recipe\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x02 \x01(\x01R\n" +
+	"similarity\"d\n" +
+	"\x0eSimilarRecipes\x126\n" +
+	"\arecipes\x18\x01 \x03(\v2\x1c.spiceroute.v1.SimilarRecipeR\arecipes\x12\x1a\n" +
+	"\bembedder\x18\x02 \x01(\tR\bembedder\"*\n" +
+	"\x0eReindexRequest\x12\x18\n" +
+	"\arebuild\x18\x01 \x01(\bR\arebuild\"\x85\x01\n" +
+	"\x11VectorIndexStatus\x12\x1a\n" +
+	"\bembedder\x18\x01 \x01(\tR\bembedder\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\x02 \x01(\x05R\n" +
+	"dimensions\x12\x18\n" +
+	"\aindexed\x18\x03 \x01(\x03R\aindexed\x12\x1a\n" +
+	"\bembedded\x18\x04 \x01(\x03R\bembedded2\x91\x05\n" +
 	"\x0eProfileService\x12H\n" +
 	"\x10UpsertPreference\x12\x19.spiceroute.v1.Preference\x1a\x19.spiceroute.v1.Preference\x12E\n" +
-	"\rGetPreference\x12\x19.spiceroute.v1.Preference\x1a\x19.spiceroute.v1.Preference2Y\n" +
+	"\rGetPreference\x12\x19.spiceroute.v1.Preference\x1a\x19.spiceroute.v1.Preference\x126\n" +
+	"\n" +
+	"CreateUser\x12\x13.spiceroute.v1.User\x1a\x13.spiceroute.v1.User\x126\n" +
+	"\aGetUser\x12\x16.spiceroute.v1.UserRef\x1a\x13.spiceroute.v1.User\x12<\n" +
+	"\n" +
+	"DeleteUser\x12\x16.spiceroute.v1.UserRef\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\x0eExportUserData\x12\x16.spiceroute.v1.UserRef\x1a\x1d.spiceroute.v1.UserDataExport\x123\n" +
+	"\aSetMood\x12\x13.spiceroute.v1.Mood\x1a\x13.spiceroute.v1.Mood\x126\n" +
+	"\aGetMood\x12\x16.spiceroute.v1.UserRef\x1a\x13.spiceroute.v1.Mood\x128\n" +
+	"\tClearMood\x12\x13.spiceroute.v1.Mood\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\x14GetEffectiveCuisines\x12\x16.spiceroute.v1.UserRef\x1a .spiceroute.v1.EffectiveCuisines2Y\n" +
 	"\x0ePlannerService\x12G\n" +
-	"\fGeneratePlan\x12\x1a.spiceroute.v1.PlanRequest\x1a\x1b.spiceroute.v1.PlanResponse2\x95\x01\n" +
+	"\fGeneratePlan\x12\x1a.spiceroute.v1.PlanRequest\x1a\x1b.spiceroute.v1.PlanResponse2\xdb\x02\n" +
+	"\vPlanService\x12<\n" +
+	"\bSavePlan\x12\x17.spiceroute.v1.MealPlan\x1a\x17.spiceroute.v1.MealPlan\x12H\n" +
+	"\tListPlans\x12\x1e.spiceroute.v1.PlanListRequest\x1a\x1b.spiceroute.v1.MealPlanList\x12=\n" +
+	"\aGetPlan\x12\x19.spiceroute.v1.PlanLookup\x1a\x17.spiceroute.v1.MealPlan\x12?\n" +
+	"\n" +
+	"DeletePlan\x12\x19.spiceroute.v1.PlanLookup\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\x0eRegeneratePlan\x12\x19.spiceroute.v1.PlanLookup\x1a\x17.spiceroute.v1.MealPlan2\x97\x04\n" +
+	"\x0fShoppingService\x12_\n" +
+	"\x14GenerateShoppingList\x12*.spiceroute.v1.GenerateShoppingListRequest\x1a\x1b.spiceroute.v1.ShoppingList\x12S\n" +
+	"\x11ListShoppingLists\x12 .spiceroute.v1.ShoppingListQuery\x1a\x1c.spiceroute.v1.ShoppingLists\x12Q\n" +
+	"\x0fGetShoppingList\x12!.spiceroute.v1.ShoppingListLookup\x1a\x1b.spiceroute.v1.ShoppingList\x12T\n" +
+	"\x12UpsertShoppingItem\x12!.spiceroute.v1.ShoppingItemUpdate\x1a\x1b.spiceroute.v1.ShoppingItem\x12W\n" +
+	"\x16SetShoppingItemChecked\x12 .spiceroute.v1.ShoppingItemCheck\x1a\x1b.spiceroute.v1.ShoppingItem\x12L\n" +
+	"\x12DeleteShoppingItem\x12\x1e.spiceroute.v1.ShoppingItemRef\x1a\x16.google.protobuf.Empty2\xee\x01\n" +
+	"\rPantryService\x12H\n" +
+	"\x10UpsertPantryItem\x12\x19.spiceroute.v1.PantryItem\x1a\x19.spiceroute.v1.PantryItem\x12I\n" +
+	"\x0fListPantryItems\x12\x1a.spiceroute.v1.PantryQuery\x1a\x1a.spiceroute.v1.PantryItems\x12H\n" +
+	"\x10DeletePantryItem\x12\x1c.spiceroute.v1.PantryItemRef\x1a\x16.google.protobuf.Empty2\xea\x05\n" +
 	"\rRecipeService\x12>\n" +
-	"\fCreateRecipe\x12\x15.spiceroute.v1.Recipe\x1a\x17.spiceroute.v1.RecipeID\x12D\n" +
-	"\vListRecipes\x12\x1a.spiceroute.v1.RecipeQuery\x1a\x19.spiceroute.v1.RecipeList2Y\n" +
-	"\x0fFeedbackService\x12F\n" +
-	"\x0eSubmitFeedback\x12\x1c.spiceroute.v1.FeedbackBatch\x1a\x16.google.protobuf.EmptyB'Z%github.com/you/spiceroute/proto;protob\x06proto3"
+	"\fCreateRecipe\x12\x15.spiceroute.v1.Recipe\x1a\x17.spiceroute.v1.RecipeID\x12;\n" +
+	"\tGetRecipe\x12\x17.spiceroute.v1.RecipeID\x1a\x15.spiceroute.v1.Recipe\x12<\n" +
+	"\fUpdateRecipe\x12\x15.spiceroute.v1.Recipe\x1a\x15.spiceroute.v1.Recipe\x12?\n" +
+	"\fDeleteRecipe\x12\x17.spiceroute.v1.RecipeID\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\rRestoreRecipe\x12\x17.spiceroute.v1.RecipeID\x1a\x15.spiceroute.v1.Recipe\x12D\n" +
+	"\vListRecipes\x12\x1a.spiceroute.v1.RecipeQuery\x1a\x19.spiceroute.v1.RecipeList\x12T\n" +
+	"\rImportRecipes\x12\x1e.spiceroute.v1.RecipeImportRow\x1a!.spiceroute.v1.RecipeImportResult(\x01\x12P\n" +
+	"\rExportRecipes\x12\".spiceroute.v1.RecipeExportRequest\x1a\x1b.spiceroute.v1.RecipeExport\x12X\n" +
+	"\rSearchRecipes\x12\".spiceroute.v1.RecipeSearchRequest\x1a#.spiceroute.v1.RecipeSearchResponse\x12T\n" +
+	"\x0eCheckAllergens\x12#.spiceroute.v1.AllergenCheckRequest\x1a\x1d.spiceroute.v1.AllergenReport2\xe6\x02\n" +
+	"\x0fFeedbackService\x12R\n" +
+	"\x0eSubmitFeedback\x12\x1c.spiceroute.v1.FeedbackBatch\x1a\".spiceroute.v1.FeedbackBatchResult\x12O\n" +
+	"\x12ListFeedbackByUser\x12\x1c.spiceroute.v1.FeedbackQuery\x1a\x1b.spiceroute.v1.FeedbackPage\x12Q\n" +
+	"\x14ListFeedbackByRecipe\x12\x1c.spiceroute.v1.FeedbackQuery\x1a\x1b.spiceroute.v1.FeedbackPage\x12[\n" +
+	"\x16GetRecipeRatingSummary\x12#.spiceroute.v1.RatingSummaryRequest\x1a\x1c.spiceroute.v1.RatingSummary2\x83\x02\n" +
+	"\rVectorService\x12T\n" +
+	"\rSearchSimilar\x12$.spiceroute.v1.SemanticSearchRequest\x1a\x1d.spiceroute.v1.SimilarRecipes\x12P\n" +
+	"\tRecommend\x12$.spiceroute.v1.RecommendationRequest\x1a\x1d.spiceroute.v1.SimilarRecipes\x12J\n" +
+	"\aReindex\x12\x1d.spiceroute.v1.ReindexRequest\x1a .spiceroute.v1.VectorIndexStatusB'Z%github.com/you/spiceroute/proto;protob\x06proto3"
 
 var (
 	file_proto_spiceroute_proto_rawDescOnce sync.Once
@@ -932,44 +4699,194 @@ func file_proto_spiceroute_proto_rawDescGZIP() []byte {
 	return file_proto_spiceroute_proto_rawDescData
 }
 
-var file_proto_spiceroute_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_spiceroute_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_proto_spiceroute_proto_goTypes = []any{
-	(*Preference)(nil),    // 0: spiceroute.v1.Preference
-	(*Mood)(nil),          // 1: spiceroute.v1.Mood
-	(*Dish)(nil),          // 2: spiceroute.v1.Dish
-	(*PlanRequest)(nil),   // 3: spiceroute.v1.PlanRequest
-	(*DailyMeals)(nil),    // 4: spiceroute.v1.DailyMeals
-	(*PlanResponse)(nil),  // 5: spiceroute.v1.PlanResponse
-	(*Recipe)(nil),        // 6: spiceroute.v1.Recipe
-	(*RecipeID)(nil),      // 7: spiceroute.v1.RecipeID
-	(*RecipeQuery)(nil),   // 8: spiceroute.v1.RecipeQuery
-	(*RecipeList)(nil),    // 9: spiceroute.v1.RecipeList
-	(*Feedback)(nil),      // 10: spiceroute.v1.Feedback
-	(*FeedbackBatch)(nil), // 11: spiceroute.v1.FeedbackBatch
-	(*emptypb.Empty)(nil), // 12: google.protobuf.Empty
+	(*Preference)(nil),                  // 0: spiceroute.v1.Preference
+	(*HouseholdMember)(nil),             // 1: spiceroute.v1.HouseholdMember
+	(*User)(nil),                        // 2: spiceroute.v1.User
+	(*UserRef)(nil),                     // 3: spiceroute.v1.UserRef
+	(*UserDataExport)(nil),              // 4: spiceroute.v1.UserDataExport
+	(*Mood)(nil),                        // 5: spiceroute.v1.Mood
+	(*EffectiveCuisines)(nil),           // 6: spiceroute.v1.EffectiveCuisines
+	(*Dish)(nil),                        // 7: spiceroute.v1.Dish
+	(*PlanRequest)(nil),                 // 8: spiceroute.v1.PlanRequest
+	(*DailyMeals)(nil),                  // 9: spiceroute.v1.DailyMeals
+	(*PlanResponse)(nil),                // 10: spiceroute.v1.PlanResponse
+	(*MealPlan)(nil),                    // 11: spiceroute.v1.MealPlan
+	(*PlanLookup)(nil),                  // 12: spiceroute.v1.PlanLookup
+	(*PlanListRequest)(nil),             // 13: spiceroute.v1.PlanListRequest
+	(*MealPlanList)(nil),                // 14: spiceroute.v1.MealPlanList
+	(*ShoppingItem)(nil),                // 15: spiceroute.v1.ShoppingItem
+	(*ShoppingAisle)(nil),               // 16: spiceroute.v1.ShoppingAisle
+	(*ShoppingList)(nil),                // 17: spiceroute.v1.ShoppingList
+	(*GenerateShoppingListRequest)(nil), // 18: spiceroute.v1.GenerateShoppingListRequest
+	(*ShoppingListLookup)(nil),          // 19: spiceroute.v1.ShoppingListLookup
+	(*ShoppingListQuery)(nil),           // 20: spiceroute.v1.ShoppingListQuery
+	(*ShoppingLists)(nil),               // 21: spiceroute.v1.ShoppingLists
+	(*ShoppingItemUpdate)(nil),          // 22: spiceroute.v1.ShoppingItemUpdate
+	(*ShoppingItemRef)(nil),             // 23: spiceroute.v1.ShoppingItemRef
+	(*ShoppingItemCheck)(nil),           // 24: spiceroute.v1.ShoppingItemCheck
+	(*PantryItem)(nil),                  // 25: spiceroute.v1.PantryItem
+	(*PantryQuery)(nil),                 // 26: spiceroute.v1.PantryQuery
+	(*PantryItems)(nil),                 // 27: spiceroute.v1.PantryItems
+	(*PantryItemRef)(nil),               // 28: spiceroute.v1.PantryItemRef
+	(*Recipe)(nil),                      // 29: spiceroute.v1.Recipe
+	(*Ingredient)(nil),                  // 30: spiceroute.v1.Ingredient
+	(*RecipeImportRow)(nil),             // 31: spiceroute.v1.RecipeImportRow
+	(*RecipeImportError)(nil),           // 32: spiceroute.v1.RecipeImportError
+	(*RecipeImportResult)(nil),          // 33: spiceroute.v1.RecipeImportResult
+	(*RecipeExportRequest)(nil),         // 34: spiceroute.v1.RecipeExportRequest
+	(*RecipeExport)(nil),                // 35: spiceroute.v1.RecipeExport
+	(*RecipeSearchRequest)(nil),         // 36: spiceroute.v1.RecipeSearchRequest
+	(*RecipeSearchHit)(nil),             // 37: spiceroute.v1.RecipeSearchHit
+	(*RecipeSearchResponse)(nil),        // 38: spiceroute.v1.RecipeSearchResponse
+	(*NutritionFacts)(nil),              // 39: spiceroute.v1.NutritionFacts
+	(*RecipeID)(nil),                    // 40: spiceroute.v1.RecipeID
+	(*RecipeQuery)(nil),                 // 41: spiceroute.v1.RecipeQuery
+	(*RecipeList)(nil),                  // 42: spiceroute.v1.RecipeList
+	(*AllergenCheckRequest)(nil),        // 43: spiceroute.v1.AllergenCheckRequest
+	(*AllergenMatch)(nil),               // 44: spiceroute.v1.AllergenMatch
+	(*RecipeAllergens)(nil),             // 45: spiceroute.v1.RecipeAllergens
+	(*AllergenReport)(nil),              // 46: spiceroute.v1.AllergenReport
+	(*Feedback)(nil),                    // 47: spiceroute.v1.Feedback
+	(*FeedbackBatch)(nil),               // 48: spiceroute.v1.FeedbackBatch
+	(*FeedbackEntryResult)(nil),         // 49: spiceroute.v1.FeedbackEntryResult
+	(*FeedbackBatchResult)(nil),         // 50: spiceroute.v1.FeedbackBatchResult
+	(*FeedbackQuery)(nil),               // 51: spiceroute.v1.FeedbackQuery
+	(*FeedbackPage)(nil),                // 52: spiceroute.v1.FeedbackPage
+	(*RatingSummaryRequest)(nil),        // 53: spiceroute.v1.RatingSummaryRequest
+	(*RatingBucket)(nil),                // 54: spiceroute.v1.RatingBucket
+	(*SubstitutionCount)(nil),           // 55: spiceroute.v1.SubstitutionCount
+	(*RatingSummary)(nil),               // 56: spiceroute.v1.RatingSummary
+	(*SemanticSearchRequest)(nil),       // 57: spiceroute.v1.SemanticSearchRequest
+	(*RecommendationRequest)(nil),       // 58: spiceroute.v1.RecommendationRequest
+	(*SimilarRecipe)(nil),               // 59: spiceroute.v1.SimilarRecipe
+	(*SimilarRecipes)(nil),              // 60: spiceroute.v1.SimilarRecipes
+	(*ReindexRequest)(nil),              // 61: spiceroute.v1.ReindexRequest
+	(*VectorIndexStatus)(nil),           // 62: spiceroute.v1.VectorIndexStatus
+	(*fieldmaskpb.FieldMask)(nil),       // 63: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),               // 64: google.protobuf.Empty
 }
 var file_proto_spiceroute_proto_depIdxs = []int32{
-	2,  // 0: spiceroute.v1.PlanRequest.dishes:type_name -> spiceroute.v1.Dish
-	4,  // 1: spiceroute.v1.PlanResponse.schedule:type_name -> spiceroute.v1.DailyMeals
-	6,  // 2: spiceroute.v1.RecipeList.recipes:type_name -> spiceroute.v1.Recipe
-	10, // 3: spiceroute.v1.FeedbackBatch.entries:type_name -> spiceroute.v1.Feedback
-	0,  // 4: spiceroute.v1.ProfileService.UpsertPreference:input_type -> spiceroute.v1.Preference
-	0,  // 5: spiceroute.v1.ProfileService.GetPreference:input_type -> spiceroute.v1.Preference
-	3,  // 6: spiceroute.v1.PlannerService.GeneratePlan:input_type -> spiceroute.v1.PlanRequest
-	6,  // 7: spiceroute.v1.RecipeService.CreateRecipe:input_type -> spiceroute.v1.Recipe
-	8,  // 8: spiceroute.v1.RecipeService.ListRecipes:input_type -> spiceroute.v1.RecipeQuery
-	11, // 9: spiceroute.v1.FeedbackService.SubmitFeedback:input_type -> spiceroute.v1.FeedbackBatch
-	0,  // 10: spiceroute.v1.ProfileService.UpsertPreference:output_type -> spiceroute.v1.Preference
-	0,  // 11: spiceroute.v1.ProfileService.GetPreference:output_type -> spiceroute.v1.Preference
-	5,  // 12: spiceroute.v1.PlannerService.GeneratePlan:output_type -> spiceroute.v1.PlanResponse
-	7,  // 13: spiceroute.v1.RecipeService.CreateRecipe:output_type -> spiceroute.v1.RecipeID
-	9,  // 14: spiceroute.v1.RecipeService.ListRecipes:output_type -> spiceroute.v1.RecipeList
-	12, // 15: spiceroute.v1.FeedbackService.SubmitFeedback:output_type -> google.protobuf.Empty
-	10, // [10:16] is the sub-list for method output_type
-	4,  // [4:10] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	1,  // 0: spiceroute.v1.Preference.members:type_name -> spiceroute.v1.HouseholdMember
+	63, // 1: spiceroute.v1.Preference.update_mask:type_name -> google.protobuf.FieldMask
+	5,  // 2: spiceroute.v1.EffectiveCuisines.mood:type_name -> spiceroute.v1.Mood
+	7,  // 3: spiceroute.v1.PlanRequest.dishes:type_name -> spiceroute.v1.Dish
+	9,  // 4: spiceroute.v1.PlanResponse.schedule:type_name -> spiceroute.v1.DailyMeals
+	8,  // 5: spiceroute.v1.MealPlan.request:type_name -> spiceroute.v1.PlanRequest
+	10, // 6: spiceroute.v1.MealPlan.plan:type_name -> spiceroute.v1.PlanResponse
+	11, // 7: spiceroute.v1.MealPlanList.plans:type_name -> spiceroute.v1.MealPlan
+	15, // 8: spiceroute.v1.ShoppingAisle.items:type_name -> spiceroute.v1.ShoppingItem
+	16, // 9: spiceroute.v1.ShoppingList.aisles:type_name -> spiceroute.v1.ShoppingAisle
+	25, // 10: spiceroute.v1.ShoppingList.expiring_pantry_items:type_name -> spiceroute.v1.PantryItem
+	17, // 11: spiceroute.v1.ShoppingLists.lists:type_name -> spiceroute.v1.ShoppingList
+	15, // 12: spiceroute.v1.ShoppingItemUpdate.item:type_name -> spiceroute.v1.ShoppingItem
+	25, // 13: spiceroute.v1.PantryItems.items:type_name -> spiceroute.v1.PantryItem
+	39, // 14: spiceroute.v1.Recipe.nutrition_facts:type_name -> spiceroute.v1.NutritionFacts
+	30, // 15: spiceroute.v1.Recipe.structured_ingredients:type_name -> spiceroute.v1.Ingredient
+	29, // 16: spiceroute.v1.RecipeImportRow.recipe:type_name -> spiceroute.v1.Recipe
+	32, // 17: spiceroute.v1.RecipeImportResult.errors:type_name -> spiceroute.v1.RecipeImportError
+	29, // 18: spiceroute.v1.RecipeSearchHit.recipe:type_name -> spiceroute.v1.Recipe
+	37, // 19: spiceroute.v1.RecipeSearchResponse.hits:type_name -> spiceroute.v1.RecipeSearchHit
+	29, // 20: spiceroute.v1.RecipeList.recipes:type_name -> spiceroute.v1.Recipe
+	44, // 21: spiceroute.v1.RecipeAllergens.matches:type_name -> spiceroute.v1.AllergenMatch
+	45, // 22: spiceroute.v1.AllergenReport.recipes:type_name -> spiceroute.v1.RecipeAllergens
+	47, // 23: spiceroute.v1.FeedbackBatch.entries:type_name -> spiceroute.v1.Feedback
+	49, // 24: spiceroute.v1.FeedbackBatchResult.results:type_name -> spiceroute.v1.FeedbackEntryResult
+	47, // 25: spiceroute.v1.FeedbackPage.entries:type_name -> spiceroute.v1.Feedback
+	54, // 26: spiceroute.v1.RatingSummary.histogram:type_name -> spiceroute.v1.RatingBucket
+	55, // 27: spiceroute.v1.RatingSummary.top_substitutions:type_name -> spiceroute.v1.SubstitutionCount
+	29, // 28: spiceroute.v1.SimilarRecipe.recipe:type_name -> spiceroute.v1.Recipe
+	59, // 29: spiceroute.v1.SimilarRecipes.recipes:type_name -> spiceroute.v1.SimilarRecipe
+	0,  // 30: spiceroute.v1.ProfileService.UpsertPreference:input_type -> spiceroute.v1.Preference
+	0,  // 31: spiceroute.v1.ProfileService.GetPreference:input_type -> spiceroute.v1.Preference
+	2,  // 32: spiceroute.v1.ProfileService.CreateUser:input_type -> spiceroute.v1.User
+	3,  // 33: spiceroute.v1.ProfileService.GetUser:input_type -> spiceroute.v1.UserRef
+	3,  // 34: spiceroute.v1.ProfileService.DeleteUser:input_type -> spiceroute.v1.UserRef
+	3,  // 35: spiceroute.v1.ProfileService.ExportUserData:input_type -> spiceroute.v1.UserRef
+	5,  // 36: spiceroute.v1.ProfileService.SetMood:input_type -> spiceroute.v1.Mood
+	3,  // 37: spiceroute.v1.ProfileService.GetMood:input_type -> spiceroute.v1.UserRef
+	5,  // 38: spiceroute.v1.ProfileService.ClearMood:input_type -> spiceroute.v1.Mood
+	3,  // 39: spiceroute.v1.ProfileService.GetEffectiveCuisines:input_type -> spiceroute.v1.UserRef
+	8,  // 40: spiceroute.v1.PlannerService.GeneratePlan:input_type -> spiceroute.v1.PlanRequest
+	11, // 41: spiceroute.v1.PlanService.SavePlan:input_type -> spiceroute.v1.MealPlan
+	13, // 42: spiceroute.v1.PlanService.ListPlans:input_type -> spiceroute.v1.PlanListRequest
+	12, // 43: spiceroute.v1.PlanService.GetPlan:input_type -> spiceroute.v1.PlanLookup
+	12, // 44: spiceroute.v1.PlanService.DeletePlan:input_type -> spiceroute.v1.PlanLookup
+	12, // 45: spiceroute.v1.PlanService.RegeneratePlan:input_type -> spiceroute.v1.PlanLookup
+	18, // 46: spiceroute.v1.ShoppingService.GenerateShoppingList:input_type -> spiceroute.v1.GenerateShoppingListRequest
+	20, // 47: spiceroute.v1.ShoppingService.ListShoppingLists:input_type -> spiceroute.v1.ShoppingListQuery
+	19, // 48: spiceroute.v1.ShoppingService.GetShoppingList:input_type -> spiceroute.v1.ShoppingListLookup
+	22, // 49: spiceroute.v1.ShoppingService.UpsertShoppingItem:input_type -> spiceroute.v1.ShoppingItemUpdate
+	24, // 50: spiceroute.v1.ShoppingService.SetShoppingItemChecked:input_type -> spiceroute.v1.ShoppingItemCheck
+	23, // 51: spiceroute.v1.ShoppingService.DeleteShoppingItem:input_type -> spiceroute.v1.ShoppingItemRef
+	25, // 52: spiceroute.v1.PantryService.UpsertPantryItem:input_type -> spiceroute.v1.PantryItem
+	26, // 53: spiceroute.v1.PantryService.ListPantryItems:input_type -> spiceroute.v1.PantryQuery
+	28, // 54: spiceroute.v1.PantryService.DeletePantryItem:input_type -> spiceroute.v1.PantryItemRef
+	29, // 55: spiceroute.v1.RecipeService.CreateRecipe:input_type -> spiceroute.v1.Recipe
+	40, // 56: spiceroute.v1.RecipeService.GetRecipe:input_type -> spiceroute.v1.RecipeID
+	29, // 57: spiceroute.v1.RecipeService.UpdateRecipe:input_type -> spiceroute.v1.Recipe
+	40, // 58: spiceroute.v1.RecipeService.DeleteRecipe:input_type -> spiceroute.v1.RecipeID
+	40, // 59: spiceroute.v1.RecipeService.RestoreRecipe:input_type -> spiceroute.v1.RecipeID
+	41, // 60: spiceroute.v1.RecipeService.ListRecipes:input_type -> spiceroute.v1.RecipeQuery
+	31, // 61: spiceroute.v1.RecipeService.ImportRecipes:input_type -> spiceroute.v1.RecipeImportRow
+	34, // 62: spiceroute.v1.RecipeService.ExportRecipes:input_type -> spiceroute.v1.RecipeExportRequest
+	36, // 63: spiceroute.v1.RecipeService.SearchRecipes:input_type -> spiceroute.v1.RecipeSearchRequest
+	43, // 64: spiceroute.v1.RecipeService.CheckAllergens:input_type -> spiceroute.v1.AllergenCheckRequest
+	48, // 65: spiceroute.v1.FeedbackService.SubmitFeedback:input_type -> spiceroute.v1.FeedbackBatch
+	51, // 66: spiceroute.v1.FeedbackService.ListFeedbackByUser:input_type -> spiceroute.v1.FeedbackQuery
+	51, // 67: spiceroute.v1.FeedbackService.ListFeedbackByRecipe:input_type -> spiceroute.v1.FeedbackQuery
+	53, // 68: spiceroute.v1.FeedbackService.GetRecipeRatingSummary:input_type -> spiceroute.v1.RatingSummaryRequest
+	57, // 69: spiceroute.v1.VectorService.SearchSimilar:input_type -> spiceroute.v1.SemanticSearchRequest
+	58, // 70: spiceroute.v1.VectorService.Recommend:input_type -> spiceroute.v1.RecommendationRequest
+	61, // 71: spiceroute.v1.VectorService.Reindex:input_type -> spiceroute.v1.ReindexRequest
+	0,  // 72: spiceroute.v1.ProfileService.UpsertPreference:output_type -> spiceroute.v1.Preference
+	0,  // 73: spiceroute.v1.ProfileService.GetPreference:output_type -> spiceroute.v1.Preference
+	2,  // 74: spiceroute.v1.ProfileService.CreateUser:output_type -> spiceroute.v1.User
+	2,  // 75: spiceroute.v1.ProfileService.GetUser:output_type -> spiceroute.v1.User
+	64, // 76: spiceroute.v1.ProfileService.DeleteUser:output_type -> google.protobuf.Empty
+	4,  // 77: spiceroute.v1.ProfileService.ExportUserData:output_type -> spiceroute.v1.UserDataExport
+	5,  // 78: spiceroute.v1.ProfileService.SetMood:output_type -> spiceroute.v1.Mood
+	5,  // 79: spiceroute.v1.ProfileService.GetMood:output_type -> spiceroute.v1.Mood
+	64, // 80: spiceroute.v1.ProfileService.ClearMood:output_type -> google.protobuf.Empty
+	6,  // 81: spiceroute.v1.ProfileService.GetEffectiveCuisines:output_type -> spiceroute.v1.EffectiveCuisines
+	10, // 82: spiceroute.v1.PlannerService.GeneratePlan:output_type -> spiceroute.v1.PlanResponse
+	11, // 83: spiceroute.v1.PlanService.SavePlan:output_type -> spiceroute.v1.MealPlan
+	14, // 84: spiceroute.v1.PlanService.ListPlans:output_type -> spiceroute.v1.MealPlanList
+	11, // 85: spiceroute.v1.PlanService.GetPlan:output_type -> spiceroute.v1.MealPlan
+	64, // 86: spiceroute.v1.PlanService.DeletePlan:output_type -> google.protobuf.Empty
+	11, // 87: spiceroute.v1.PlanService.RegeneratePlan:output_type -> spiceroute.v1.MealPlan
+	17, // 88: spiceroute.v1.ShoppingService.GenerateShoppingList:output_type -> spiceroute.v1.ShoppingList
+	21, // 89: spiceroute.v1.ShoppingService.ListShoppingLists:output_type -> spiceroute.v1.ShoppingLists
+	17, // 90: spiceroute.v1.ShoppingService.GetShoppingList:output_type -> spiceroute.v1.ShoppingList
+	15, // 91: spiceroute.v1.ShoppingService.UpsertShoppingItem:output_type -> spiceroute.v1.ShoppingItem
+	15, // 92: spiceroute.v1.ShoppingService.SetShoppingItemChecked:output_type -> spiceroute.v1.ShoppingItem
+	64, // 93: spiceroute.v1.ShoppingService.DeleteShoppingItem:output_type -> google.protobuf.Empty
+	25, // 94: spiceroute.v1.PantryService.UpsertPantryItem:output_type -> spiceroute.v1.PantryItem
+	27, // 95: spiceroute.v1.PantryService.ListPantryItems:output_type -> spiceroute.v1.PantryItems
+	64, // 96: spiceroute.v1.PantryService.DeletePantryItem:output_type -> google.protobuf.Empty
+	40, // 97: spiceroute.v1.RecipeService.CreateRecipe:output_type -> spiceroute.v1.RecipeID
+	29, // 98: spiceroute.v1.RecipeService.GetRecipe:output_type -> spiceroute.v1.Recipe
+	29, // 99: spiceroute.v1.RecipeService.UpdateRecipe:output_type -> spiceroute.v1.Recipe
+	64, // 100: spiceroute.v1.RecipeService.DeleteRecipe:output_type -> google.protobuf.Empty
+	29, // 101: spiceroute.v1.RecipeService.RestoreRecipe:output_type -> spiceroute.v1.Recipe
+	42, // 102: spiceroute.v1.RecipeService.ListRecipes:output_type -> spiceroute.v1.RecipeList
+	33, // 103: spiceroute.v1.RecipeService.ImportRecipes:output_type -> spiceroute.v1.RecipeImportResult
+	35, // 104: spiceroute.v1.RecipeService.ExportRecipes:output_type -> spiceroute.v1.RecipeExport
+	38, // 105: spiceroute.v1.RecipeService.SearchRecipes:output_type -> spiceroute.v1.RecipeSearchResponse
+	46, // 106: spiceroute.v1.RecipeService.CheckAllergens:output_type -> spiceroute.v1.AllergenReport
+	50, // 107: spiceroute.v1.FeedbackService.SubmitFeedback:output_type -> spiceroute.v1.FeedbackBatchResult
+	52, // 108: spiceroute.v1.FeedbackService.ListFeedbackByUser:output_type -> spiceroute.v1.FeedbackPage
+	52, // 109: spiceroute.v1.FeedbackService.ListFeedbackByRecipe:output_type -> spiceroute.v1.FeedbackPage
+	56, // 110: spiceroute.v1.FeedbackService.GetRecipeRatingSummary:output_type -> spiceroute.v1.RatingSummary
+	60, // 111: spiceroute.v1.VectorService.SearchSimilar:output_type -> spiceroute.v1.SimilarRecipes
+	60, // 112: spiceroute.v1.VectorService.Recommend:output_type -> spiceroute.v1.SimilarRecipes
+	62, // 113: spiceroute.v1.VectorService.Reindex:output_type -> spiceroute.v1.VectorIndexStatus
+	72, // [72:114] is the sub-list for method output_type
+	30, // [30:72] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_proto_spiceroute_proto_init() }
@@ -983,9 +4900,9 @@ func file_proto_spiceroute_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_spiceroute_proto_rawDesc), len(file_proto_spiceroute_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   63,
 			NumExtensions: 0,
-			NumServices:   4,
+			NumServices:   8,
 		},
 		GoTypes:           file_proto_spiceroute_proto_goTypes,
 		DependencyIndexes: file_proto_spiceroute_proto_depIdxs,
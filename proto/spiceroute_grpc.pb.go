@@ -20,8 +20,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProfileService_UpsertPreference_FullMethodName = "/spiceroute.v1.ProfileService/UpsertPreference"
-	ProfileService_GetPreference_FullMethodName    = "/spiceroute.v1.ProfileService/GetPreference"
+	ProfileService_UpsertPreference_FullMethodName     = "/spiceroute.v1.ProfileService/UpsertPreference"
+	ProfileService_GetPreference_FullMethodName        = "/spiceroute.v1.ProfileService/GetPreference"
+	ProfileService_CreateUser_FullMethodName           = "/spiceroute.v1.ProfileService/CreateUser"
+	ProfileService_GetUser_FullMethodName              = "/spiceroute.v1.ProfileService/GetUser"
+	ProfileService_DeleteUser_FullMethodName           = "/spiceroute.v1.ProfileService/DeleteUser"
+	ProfileService_ExportUserData_FullMethodName       = "/spiceroute.v1.ProfileService/ExportUserData"
+	ProfileService_SetMood_FullMethodName              = "/spiceroute.v1.ProfileService/SetMood"
+	ProfileService_GetMood_FullMethodName              = "/spiceroute.v1.ProfileService/GetMood"
+	ProfileService_ClearMood_FullMethodName            = "/spiceroute.v1.ProfileService/ClearMood"
+	ProfileService_GetEffectiveCuisines_FullMethodName = "/spiceroute.v1.ProfileService/GetEffectiveCuisines"
 )
 
 // ProfileServiceClient is the client API for ProfileService service.
@@ -30,6 +38,14 @@ const (
 type ProfileServiceClient interface {
 	UpsertPreference(ctx context.Context, in *Preference, opts ...grpc.CallOption) (*Preference, error)
 	GetPreference(ctx context.Context, in *Preference, opts ...grpc.CallOption) (*Preference, error)
+	CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error)
+	GetUser(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*User, error)
+	DeleteUser(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ExportUserData(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*UserDataExport, error)
+	SetMood(ctx context.Context, in *Mood, opts ...grpc.CallOption) (*Mood, error)
+	GetMood(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*Mood, error)
+	ClearMood(ctx context.Context, in *Mood, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetEffectiveCuisines(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*EffectiveCuisines, error)
 }
 
 type profileServiceClient struct {
@@ -60,12 +76,100 @@ func (c *profileServiceClient) GetPreference(ctx context.Context, in *Preference
 	return out, nil
 }
 
+func (c *profileServiceClient) CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, ProfileService_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) GetUser(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, ProfileService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) DeleteUser(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProfileService_DeleteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) ExportUserData(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*UserDataExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserDataExport)
+	err := c.cc.Invoke(ctx, ProfileService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) SetMood(ctx context.Context, in *Mood, opts ...grpc.CallOption) (*Mood, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mood)
+	err := c.cc.Invoke(ctx, ProfileService_SetMood_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) GetMood(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*Mood, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Mood)
+	err := c.cc.Invoke(ctx, ProfileService_GetMood_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) ClearMood(ctx context.Context, in *Mood, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProfileService_ClearMood_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) GetEffectiveCuisines(ctx context.Context, in *UserRef, opts ...grpc.CallOption) (*EffectiveCuisines, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EffectiveCuisines)
+	err := c.cc.Invoke(ctx, ProfileService_GetEffectiveCuisines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProfileServiceServer is the server API for ProfileService service.
 // All implementations must embed UnimplementedProfileServiceServer
 // for forward compatibility.
 type ProfileServiceServer interface {
 	UpsertPreference(context.Context, *Preference) (*Preference, error)
 	GetPreference(context.Context, *Preference) (*Preference, error)
+	CreateUser(context.Context, *User) (*User, error)
+	GetUser(context.Context, *UserRef) (*User, error)
+	DeleteUser(context.Context, *UserRef) (*emptypb.Empty, error)
+	ExportUserData(context.Context, *UserRef) (*UserDataExport, error)
+	SetMood(context.Context, *Mood) (*Mood, error)
+	GetMood(context.Context, *UserRef) (*Mood, error)
+	ClearMood(context.Context, *Mood) (*emptypb.Empty, error)
+	GetEffectiveCuisines(context.Context, *UserRef) (*EffectiveCuisines, error)
 	mustEmbedUnimplementedProfileServiceServer()
 }
 
@@ -82,6 +186,30 @@ func (UnimplementedProfileServiceServer) UpsertPreference(context.Context, *Pref
 func (UnimplementedProfileServiceServer) GetPreference(context.Context, *Preference) (*Preference, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPreference not implemented")
 }
+func (UnimplementedProfileServiceServer) CreateUser(context.Context, *User) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedProfileServiceServer) GetUser(context.Context, *UserRef) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedProfileServiceServer) DeleteUser(context.Context, *UserRef) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedProfileServiceServer) ExportUserData(context.Context, *UserRef) (*UserDataExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedProfileServiceServer) SetMood(context.Context, *Mood) (*Mood, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMood not implemented")
+}
+func (UnimplementedProfileServiceServer) GetMood(context.Context, *UserRef) (*Mood, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMood not implemented")
+}
+func (UnimplementedProfileServiceServer) ClearMood(context.Context, *Mood) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearMood not implemented")
+}
+func (UnimplementedProfileServiceServer) GetEffectiveCuisines(context.Context, *UserRef) (*EffectiveCuisines, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEffectiveCuisines not implemented")
+}
 func (UnimplementedProfileServiceServer) mustEmbedUnimplementedProfileServiceServer() {}
 func (UnimplementedProfileServiceServer) testEmbeddedByValue()                        {}
 
@@ -139,6 +267,150 @@ func _ProfileService_GetPreference_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).CreateUser(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetUser(ctx, req.(*UserRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).DeleteUser(ctx, req.(*UserRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).ExportUserData(ctx, req.(*UserRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_SetMood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Mood)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).SetMood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_SetMood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).SetMood(ctx, req.(*Mood))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_GetMood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetMood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetMood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetMood(ctx, req.(*UserRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_ClearMood_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Mood)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).ClearMood(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_ClearMood_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).ClearMood(ctx, req.(*Mood))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_GetEffectiveCuisines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetEffectiveCuisines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetEffectiveCuisines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetEffectiveCuisines(ctx, req.(*UserRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProfileService_ServiceDesc is the grpc.ServiceDesc for ProfileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,6 +426,38 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPreference",
 			Handler:    _ProfileService_GetPreference_Handler,
 		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _ProfileService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _ProfileService_GetUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _ProfileService_DeleteUser_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _ProfileService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "SetMood",
+			Handler:    _ProfileService_SetMood_Handler,
+		},
+		{
+			MethodName: "GetMood",
+			Handler:    _ProfileService_GetMood_Handler,
+		},
+		{
+			MethodName: "ClearMood",
+			Handler:    _ProfileService_ClearMood_Handler,
+		},
+		{
+			MethodName: "GetEffectiveCuisines",
+			Handler:    _ProfileService_GetEffectiveCuisines_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/spiceroute.proto",
@@ -262,126 +566,1114 @@ var PlannerService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	RecipeService_CreateRecipe_FullMethodName = "/spiceroute.v1.RecipeService/CreateRecipe"
-	RecipeService_ListRecipes_FullMethodName  = "/spiceroute.v1.RecipeService/ListRecipes"
+	PlanService_SavePlan_FullMethodName       = "/spiceroute.v1.PlanService/SavePlan"
+	PlanService_ListPlans_FullMethodName      = "/spiceroute.v1.PlanService/ListPlans"
+	PlanService_GetPlan_FullMethodName        = "/spiceroute.v1.PlanService/GetPlan"
+	PlanService_DeletePlan_FullMethodName     = "/spiceroute.v1.PlanService/DeletePlan"
+	PlanService_RegeneratePlan_FullMethodName = "/spiceroute.v1.PlanService/RegeneratePlan"
 )
 
-// RecipeServiceClient is the client API for RecipeService service.
+// PlanServiceClient is the client API for PlanService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type RecipeServiceClient interface {
-	CreateRecipe(ctx context.Context, in *Recipe, opts ...grpc.CallOption) (*RecipeID, error)
-	ListRecipes(ctx context.Context, in *RecipeQuery, opts ...grpc.CallOption) (*RecipeList, error)
+type PlanServiceClient interface {
+	SavePlan(ctx context.Context, in *MealPlan, opts ...grpc.CallOption) (*MealPlan, error)
+	ListPlans(ctx context.Context, in *PlanListRequest, opts ...grpc.CallOption) (*MealPlanList, error)
+	GetPlan(ctx context.Context, in *PlanLookup, opts ...grpc.CallOption) (*MealPlan, error)
+	DeletePlan(ctx context.Context, in *PlanLookup, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RegeneratePlan(ctx context.Context, in *PlanLookup, opts ...grpc.CallOption) (*MealPlan, error)
 }
 
-type recipeServiceClient struct {
+type planServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewRecipeServiceClient(cc grpc.ClientConnInterface) RecipeServiceClient {
-	return &recipeServiceClient{cc}
+func NewPlanServiceClient(cc grpc.ClientConnInterface) PlanServiceClient {
+	return &planServiceClient{cc}
 }
 
-func (c *recipeServiceClient) CreateRecipe(ctx context.Context, in *Recipe, opts ...grpc.CallOption) (*RecipeID, error) {
+func (c *planServiceClient) SavePlan(ctx context.Context, in *MealPlan, opts ...grpc.CallOption) (*MealPlan, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RecipeID)
-	err := c.cc.Invoke(ctx, RecipeService_CreateRecipe_FullMethodName, in, out, cOpts...)
+	out := new(MealPlan)
+	err := c.cc.Invoke(ctx, PlanService_SavePlan_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *recipeServiceClient) ListRecipes(ctx context.Context, in *RecipeQuery, opts ...grpc.CallOption) (*RecipeList, error) {
+func (c *planServiceClient) ListPlans(ctx context.Context, in *PlanListRequest, opts ...grpc.CallOption) (*MealPlanList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RecipeList)
-	err := c.cc.Invoke(ctx, RecipeService_ListRecipes_FullMethodName, in, out, cOpts...)
+	out := new(MealPlanList)
+	err := c.cc.Invoke(ctx, PlanService_ListPlans_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// RecipeServiceServer is the server API for RecipeService service.
-// All implementations must embed UnimplementedRecipeServiceServer
+func (c *planServiceClient) GetPlan(ctx context.Context, in *PlanLookup, opts ...grpc.CallOption) (*MealPlan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MealPlan)
+	err := c.cc.Invoke(ctx, PlanService_GetPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planServiceClient) DeletePlan(ctx context.Context, in *PlanLookup, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PlanService_DeletePlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *planServiceClient) RegeneratePlan(ctx context.Context, in *PlanLookup, opts ...grpc.CallOption) (*MealPlan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MealPlan)
+	err := c.cc.Invoke(ctx, PlanService_RegeneratePlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlanServiceServer is the server API for PlanService service.
+// All implementations must embed UnimplementedPlanServiceServer
 // for forward compatibility.
-type RecipeServiceServer interface {
-	CreateRecipe(context.Context, *Recipe) (*RecipeID, error)
-	ListRecipes(context.Context, *RecipeQuery) (*RecipeList, error)
-	mustEmbedUnimplementedRecipeServiceServer()
+type PlanServiceServer interface {
+	SavePlan(context.Context, *MealPlan) (*MealPlan, error)
+	ListPlans(context.Context, *PlanListRequest) (*MealPlanList, error)
+	GetPlan(context.Context, *PlanLookup) (*MealPlan, error)
+	DeletePlan(context.Context, *PlanLookup) (*emptypb.Empty, error)
+	RegeneratePlan(context.Context, *PlanLookup) (*MealPlan, error)
+	mustEmbedUnimplementedPlanServiceServer()
 }
 
-// UnimplementedRecipeServiceServer must be embedded to have
+// UnimplementedPlanServiceServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedRecipeServiceServer struct{}
+type UnimplementedPlanServiceServer struct{}
 
-func (UnimplementedRecipeServiceServer) CreateRecipe(context.Context, *Recipe) (*RecipeID, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRecipe not implemented")
+func (UnimplementedPlanServiceServer) SavePlan(context.Context, *MealPlan) (*MealPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SavePlan not implemented")
 }
-func (UnimplementedRecipeServiceServer) ListRecipes(context.Context, *RecipeQuery) (*RecipeList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListRecipes not implemented")
+func (UnimplementedPlanServiceServer) ListPlans(context.Context, *PlanListRequest) (*MealPlanList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlans not implemented")
 }
-func (UnimplementedRecipeServiceServer) mustEmbedUnimplementedRecipeServiceServer() {}
-func (UnimplementedRecipeServiceServer) testEmbeddedByValue()                       {}
+func (UnimplementedPlanServiceServer) GetPlan(context.Context, *PlanLookup) (*MealPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlan not implemented")
+}
+func (UnimplementedPlanServiceServer) DeletePlan(context.Context, *PlanLookup) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePlan not implemented")
+}
+func (UnimplementedPlanServiceServer) RegeneratePlan(context.Context, *PlanLookup) (*MealPlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegeneratePlan not implemented")
+}
+func (UnimplementedPlanServiceServer) mustEmbedUnimplementedPlanServiceServer() {}
+func (UnimplementedPlanServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeRecipeServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to RecipeServiceServer will
+// UnsafePlanServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlanServiceServer will
 // result in compilation errors.
-type UnsafeRecipeServiceServer interface {
-	mustEmbedUnimplementedRecipeServiceServer()
+type UnsafePlanServiceServer interface {
+	mustEmbedUnimplementedPlanServiceServer()
 }
 
-func RegisterRecipeServiceServer(s grpc.ServiceRegistrar, srv RecipeServiceServer) {
-	// If the following call pancis, it indicates UnimplementedRecipeServiceServer was
+func RegisterPlanServiceServer(s grpc.ServiceRegistrar, srv PlanServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPlanServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&RecipeService_ServiceDesc, srv)
+	s.RegisterService(&PlanService_ServiceDesc, srv)
 }
 
-func _RecipeService_CreateRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Recipe)
+func _PlanService_SavePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MealPlan)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RecipeServiceServer).CreateRecipe(ctx, in)
+		return srv.(PlanServiceServer).SavePlan(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RecipeService_CreateRecipe_FullMethodName,
+		FullMethod: PlanService_SavePlan_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RecipeServiceServer).CreateRecipe(ctx, req.(*Recipe))
+		return srv.(PlanServiceServer).SavePlan(ctx, req.(*MealPlan))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RecipeService_ListRecipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RecipeQuery)
+func _PlanService_ListPlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RecipeServiceServer).ListRecipes(ctx, in)
+		return srv.(PlanServiceServer).ListPlans(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RecipeService_ListRecipes_FullMethodName,
+		FullMethod: PlanService_ListPlans_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RecipeServiceServer).ListRecipes(ctx, req.(*RecipeQuery))
+		return srv.(PlanServiceServer).ListPlans(ctx, req.(*PlanListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// RecipeService_ServiceDesc is the grpc.ServiceDesc for RecipeService service.
+func _PlanService_GetPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanLookup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanServiceServer).GetPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanService_GetPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanServiceServer).GetPlan(ctx, req.(*PlanLookup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanService_DeletePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanLookup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanServiceServer).DeletePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanService_DeletePlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanServiceServer).DeletePlan(ctx, req.(*PlanLookup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlanService_RegeneratePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanLookup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanServiceServer).RegeneratePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanService_RegeneratePlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanServiceServer).RegeneratePlan(ctx, req.(*PlanLookup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PlanService_ServiceDesc is the grpc.ServiceDesc for PlanService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PlanService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spiceroute.v1.PlanService",
+	HandlerType: (*PlanServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SavePlan",
+			Handler:    _PlanService_SavePlan_Handler,
+		},
+		{
+			MethodName: "ListPlans",
+			Handler:    _PlanService_ListPlans_Handler,
+		},
+		{
+			MethodName: "GetPlan",
+			Handler:    _PlanService_GetPlan_Handler,
+		},
+		{
+			MethodName: "DeletePlan",
+			Handler:    _PlanService_DeletePlan_Handler,
+		},
+		{
+			MethodName: "RegeneratePlan",
+			Handler:    _PlanService_RegeneratePlan_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/spiceroute.proto",
+}
+
+const (
+	ShoppingService_GenerateShoppingList_FullMethodName   = "/spiceroute.v1.ShoppingService/GenerateShoppingList"
+	ShoppingService_ListShoppingLists_FullMethodName      = "/spiceroute.v1.ShoppingService/ListShoppingLists"
+	ShoppingService_GetShoppingList_FullMethodName        = "/spiceroute.v1.ShoppingService/GetShoppingList"
+	ShoppingService_UpsertShoppingItem_FullMethodName     = "/spiceroute.v1.ShoppingService/UpsertShoppingItem"
+	ShoppingService_SetShoppingItemChecked_FullMethodName = "/spiceroute.v1.ShoppingService/SetShoppingItemChecked"
+	ShoppingService_DeleteShoppingItem_FullMethodName     = "/spiceroute.v1.ShoppingService/DeleteShoppingItem"
+)
+
+// ShoppingServiceClient is the client API for ShoppingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ShoppingServiceClient interface {
+	GenerateShoppingList(ctx context.Context, in *GenerateShoppingListRequest, opts ...grpc.CallOption) (*ShoppingList, error)
+	ListShoppingLists(ctx context.Context, in *ShoppingListQuery, opts ...grpc.CallOption) (*ShoppingLists, error)
+	GetShoppingList(ctx context.Context, in *ShoppingListLookup, opts ...grpc.CallOption) (*ShoppingList, error)
+	UpsertShoppingItem(ctx context.Context, in *ShoppingItemUpdate, opts ...grpc.CallOption) (*ShoppingItem, error)
+	SetShoppingItemChecked(ctx context.Context, in *ShoppingItemCheck, opts ...grpc.CallOption) (*ShoppingItem, error)
+	DeleteShoppingItem(ctx context.Context, in *ShoppingItemRef, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type shoppingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewShoppingServiceClient(cc grpc.ClientConnInterface) ShoppingServiceClient {
+	return &shoppingServiceClient{cc}
+}
+
+func (c *shoppingServiceClient) GenerateShoppingList(ctx context.Context, in *GenerateShoppingListRequest, opts ...grpc.CallOption) (*ShoppingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingList)
+	err := c.cc.Invoke(ctx, ShoppingService_GenerateShoppingList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingServiceClient) ListShoppingLists(ctx context.Context, in *ShoppingListQuery, opts ...grpc.CallOption) (*ShoppingLists, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingLists)
+	err := c.cc.Invoke(ctx, ShoppingService_ListShoppingLists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingServiceClient) GetShoppingList(ctx context.Context, in *ShoppingListLookup, opts ...grpc.CallOption) (*ShoppingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingList)
+	err := c.cc.Invoke(ctx, ShoppingService_GetShoppingList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingServiceClient) UpsertShoppingItem(ctx context.Context, in *ShoppingItemUpdate, opts ...grpc.CallOption) (*ShoppingItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingItem)
+	err := c.cc.Invoke(ctx, ShoppingService_UpsertShoppingItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingServiceClient) SetShoppingItemChecked(ctx context.Context, in *ShoppingItemCheck, opts ...grpc.CallOption) (*ShoppingItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShoppingItem)
+	err := c.cc.Invoke(ctx, ShoppingService_SetShoppingItemChecked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shoppingServiceClient) DeleteShoppingItem(ctx context.Context, in *ShoppingItemRef, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ShoppingService_DeleteShoppingItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShoppingServiceServer is the server API for ShoppingService service.
+// All implementations must embed UnimplementedShoppingServiceServer
+// for forward compatibility.
+type ShoppingServiceServer interface {
+	GenerateShoppingList(context.Context, *GenerateShoppingListRequest) (*ShoppingList, error)
+	ListShoppingLists(context.Context, *ShoppingListQuery) (*ShoppingLists, error)
+	GetShoppingList(context.Context, *ShoppingListLookup) (*ShoppingList, error)
+	UpsertShoppingItem(context.Context, *ShoppingItemUpdate) (*ShoppingItem, error)
+	SetShoppingItemChecked(context.Context, *ShoppingItemCheck) (*ShoppingItem, error)
+	DeleteShoppingItem(context.Context, *ShoppingItemRef) (*emptypb.Empty, error)
+	mustEmbedUnimplementedShoppingServiceServer()
+}
+
+// UnimplementedShoppingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedShoppingServiceServer struct{}
+
+func (UnimplementedShoppingServiceServer) GenerateShoppingList(context.Context, *GenerateShoppingListRequest) (*ShoppingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateShoppingList not implemented")
+}
+func (UnimplementedShoppingServiceServer) ListShoppingLists(context.Context, *ShoppingListQuery) (*ShoppingLists, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShoppingLists not implemented")
+}
+func (UnimplementedShoppingServiceServer) GetShoppingList(context.Context, *ShoppingListLookup) (*ShoppingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShoppingList not implemented")
+}
+func (UnimplementedShoppingServiceServer) UpsertShoppingItem(context.Context, *ShoppingItemUpdate) (*ShoppingItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertShoppingItem not implemented")
+}
+func (UnimplementedShoppingServiceServer) SetShoppingItemChecked(context.Context, *ShoppingItemCheck) (*ShoppingItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetShoppingItemChecked not implemented")
+}
+func (UnimplementedShoppingServiceServer) DeleteShoppingItem(context.Context, *ShoppingItemRef) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShoppingItem not implemented")
+}
+func (UnimplementedShoppingServiceServer) mustEmbedUnimplementedShoppingServiceServer() {}
+func (UnimplementedShoppingServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeShoppingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShoppingServiceServer will
+// result in compilation errors.
+type UnsafeShoppingServiceServer interface {
+	mustEmbedUnimplementedShoppingServiceServer()
+}
+
+func RegisterShoppingServiceServer(s grpc.ServiceRegistrar, srv ShoppingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedShoppingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ShoppingService_ServiceDesc, srv)
+}
+
+func _ShoppingService_GenerateShoppingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateShoppingListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingServiceServer).GenerateShoppingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingService_GenerateShoppingList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingServiceServer).GenerateShoppingList(ctx, req.(*GenerateShoppingListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingService_ListShoppingLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShoppingListQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingServiceServer).ListShoppingLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingService_ListShoppingLists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingServiceServer).ListShoppingLists(ctx, req.(*ShoppingListQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingService_GetShoppingList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShoppingListLookup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingServiceServer).GetShoppingList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingService_GetShoppingList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingServiceServer).GetShoppingList(ctx, req.(*ShoppingListLookup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingService_UpsertShoppingItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShoppingItemUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingServiceServer).UpsertShoppingItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingService_UpsertShoppingItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingServiceServer).UpsertShoppingItem(ctx, req.(*ShoppingItemUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingService_SetShoppingItemChecked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShoppingItemCheck)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingServiceServer).SetShoppingItemChecked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingService_SetShoppingItemChecked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingServiceServer).SetShoppingItemChecked(ctx, req.(*ShoppingItemCheck))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShoppingService_DeleteShoppingItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShoppingItemRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShoppingServiceServer).DeleteShoppingItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ShoppingService_DeleteShoppingItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShoppingServiceServer).DeleteShoppingItem(ctx, req.(*ShoppingItemRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ShoppingService_ServiceDesc is the grpc.ServiceDesc for ShoppingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ShoppingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spiceroute.v1.ShoppingService",
+	HandlerType: (*ShoppingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenerateShoppingList",
+			Handler:    _ShoppingService_GenerateShoppingList_Handler,
+		},
+		{
+			MethodName: "ListShoppingLists",
+			Handler:    _ShoppingService_ListShoppingLists_Handler,
+		},
+		{
+			MethodName: "GetShoppingList",
+			Handler:    _ShoppingService_GetShoppingList_Handler,
+		},
+		{
+			MethodName: "UpsertShoppingItem",
+			Handler:    _ShoppingService_UpsertShoppingItem_Handler,
+		},
+		{
+			MethodName: "SetShoppingItemChecked",
+			Handler:    _ShoppingService_SetShoppingItemChecked_Handler,
+		},
+		{
+			MethodName: "DeleteShoppingItem",
+			Handler:    _ShoppingService_DeleteShoppingItem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/spiceroute.proto",
+}
+
+const (
+	PantryService_UpsertPantryItem_FullMethodName = "/spiceroute.v1.PantryService/UpsertPantryItem"
+	PantryService_ListPantryItems_FullMethodName  = "/spiceroute.v1.PantryService/ListPantryItems"
+	PantryService_DeletePantryItem_FullMethodName = "/spiceroute.v1.PantryService/DeletePantryItem"
+)
+
+// PantryServiceClient is the client API for PantryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PantryServiceClient interface {
+	UpsertPantryItem(ctx context.Context, in *PantryItem, opts ...grpc.CallOption) (*PantryItem, error)
+	ListPantryItems(ctx context.Context, in *PantryQuery, opts ...grpc.CallOption) (*PantryItems, error)
+	DeletePantryItem(ctx context.Context, in *PantryItemRef, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type pantryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPantryServiceClient(cc grpc.ClientConnInterface) PantryServiceClient {
+	return &pantryServiceClient{cc}
+}
+
+func (c *pantryServiceClient) UpsertPantryItem(ctx context.Context, in *PantryItem, opts ...grpc.CallOption) (*PantryItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PantryItem)
+	err := c.cc.Invoke(ctx, PantryService_UpsertPantryItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pantryServiceClient) ListPantryItems(ctx context.Context, in *PantryQuery, opts ...grpc.CallOption) (*PantryItems, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PantryItems)
+	err := c.cc.Invoke(ctx, PantryService_ListPantryItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pantryServiceClient) DeletePantryItem(ctx context.Context, in *PantryItemRef, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PantryService_DeletePantryItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PantryServiceServer is the server API for PantryService service.
+// All implementations must embed UnimplementedPantryServiceServer
+// for forward compatibility.
+type PantryServiceServer interface {
+	UpsertPantryItem(context.Context, *PantryItem) (*PantryItem, error)
+	ListPantryItems(context.Context, *PantryQuery) (*PantryItems, error)
+	DeletePantryItem(context.Context, *PantryItemRef) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPantryServiceServer()
+}
+
+// UnimplementedPantryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPantryServiceServer struct{}
+
+func (UnimplementedPantryServiceServer) UpsertPantryItem(context.Context, *PantryItem) (*PantryItem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertPantryItem not implemented")
+}
+func (UnimplementedPantryServiceServer) ListPantryItems(context.Context, *PantryQuery) (*PantryItems, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPantryItems not implemented")
+}
+func (UnimplementedPantryServiceServer) DeletePantryItem(context.Context, *PantryItemRef) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePantryItem not implemented")
+}
+func (UnimplementedPantryServiceServer) mustEmbedUnimplementedPantryServiceServer() {}
+func (UnimplementedPantryServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafePantryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PantryServiceServer will
+// result in compilation errors.
+type UnsafePantryServiceServer interface {
+	mustEmbedUnimplementedPantryServiceServer()
+}
+
+func RegisterPantryServiceServer(s grpc.ServiceRegistrar, srv PantryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPantryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PantryService_ServiceDesc, srv)
+}
+
+func _PantryService_UpsertPantryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PantryItem)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PantryServiceServer).UpsertPantryItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PantryService_UpsertPantryItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PantryServiceServer).UpsertPantryItem(ctx, req.(*PantryItem))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PantryService_ListPantryItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PantryQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PantryServiceServer).ListPantryItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PantryService_ListPantryItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PantryServiceServer).ListPantryItems(ctx, req.(*PantryQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PantryService_DeletePantryItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PantryItemRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PantryServiceServer).DeletePantryItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PantryService_DeletePantryItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PantryServiceServer).DeletePantryItem(ctx, req.(*PantryItemRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PantryService_ServiceDesc is the grpc.ServiceDesc for PantryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PantryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spiceroute.v1.PantryService",
+	HandlerType: (*PantryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpsertPantryItem",
+			Handler:    _PantryService_UpsertPantryItem_Handler,
+		},
+		{
+			MethodName: "ListPantryItems",
+			Handler:    _PantryService_ListPantryItems_Handler,
+		},
+		{
+			MethodName: "DeletePantryItem",
+			Handler:    _PantryService_DeletePantryItem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/spiceroute.proto",
+}
+
+const (
+	RecipeService_CreateRecipe_FullMethodName   = "/spiceroute.v1.RecipeService/CreateRecipe"
+	RecipeService_GetRecipe_FullMethodName      = "/spiceroute.v1.RecipeService/GetRecipe"
+	RecipeService_UpdateRecipe_FullMethodName   = "/spiceroute.v1.RecipeService/UpdateRecipe"
+	RecipeService_DeleteRecipe_FullMethodName   = "/spiceroute.v1.RecipeService/DeleteRecipe"
+	RecipeService_RestoreRecipe_FullMethodName  = "/spiceroute.v1.RecipeService/RestoreRecipe"
+	RecipeService_ListRecipes_FullMethodName    = "/spiceroute.v1.RecipeService/ListRecipes"
+	RecipeService_ImportRecipes_FullMethodName  = "/spiceroute.v1.RecipeService/ImportRecipes"
+	RecipeService_ExportRecipes_FullMethodName  = "/spiceroute.v1.RecipeService/ExportRecipes"
+	RecipeService_SearchRecipes_FullMethodName  = "/spiceroute.v1.RecipeService/SearchRecipes"
+	RecipeService_CheckAllergens_FullMethodName = "/spiceroute.v1.RecipeService/CheckAllergens"
+)
+
+// RecipeServiceClient is the client API for RecipeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RecipeServiceClient interface {
+	CreateRecipe(ctx context.Context, in *Recipe, opts ...grpc.CallOption) (*RecipeID, error)
+	GetRecipe(ctx context.Context, in *RecipeID, opts ...grpc.CallOption) (*Recipe, error)
+	UpdateRecipe(ctx context.Context, in *Recipe, opts ...grpc.CallOption) (*Recipe, error)
+	DeleteRecipe(ctx context.Context, in *RecipeID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreRecipe(ctx context.Context, in *RecipeID, opts ...grpc.CallOption) (*Recipe, error)
+	ListRecipes(ctx context.Context, in *RecipeQuery, opts ...grpc.CallOption) (*RecipeList, error)
+	ImportRecipes(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RecipeImportRow, RecipeImportResult], error)
+	ExportRecipes(ctx context.Context, in *RecipeExportRequest, opts ...grpc.CallOption) (*RecipeExport, error)
+	SearchRecipes(ctx context.Context, in *RecipeSearchRequest, opts ...grpc.CallOption) (*RecipeSearchResponse, error)
+	CheckAllergens(ctx context.Context, in *AllergenCheckRequest, opts ...grpc.CallOption) (*AllergenReport, error)
+}
+
+type recipeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecipeServiceClient(cc grpc.ClientConnInterface) RecipeServiceClient {
+	return &recipeServiceClient{cc}
+}
+
+func (c *recipeServiceClient) CreateRecipe(ctx context.Context, in *Recipe, opts ...grpc.CallOption) (*RecipeID, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecipeID)
+	err := c.cc.Invoke(ctx, RecipeService_CreateRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) GetRecipe(ctx context.Context, in *RecipeID, opts ...grpc.CallOption) (*Recipe, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Recipe)
+	err := c.cc.Invoke(ctx, RecipeService_GetRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) UpdateRecipe(ctx context.Context, in *Recipe, opts ...grpc.CallOption) (*Recipe, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Recipe)
+	err := c.cc.Invoke(ctx, RecipeService_UpdateRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) DeleteRecipe(ctx context.Context, in *RecipeID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RecipeService_DeleteRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) RestoreRecipe(ctx context.Context, in *RecipeID, opts ...grpc.CallOption) (*Recipe, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Recipe)
+	err := c.cc.Invoke(ctx, RecipeService_RestoreRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) ListRecipes(ctx context.Context, in *RecipeQuery, opts ...grpc.CallOption) (*RecipeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecipeList)
+	err := c.cc.Invoke(ctx, RecipeService_ListRecipes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) ImportRecipes(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RecipeImportRow, RecipeImportResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RecipeService_ServiceDesc.Streams[0], RecipeService_ImportRecipes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RecipeImportRow, RecipeImportResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RecipeService_ImportRecipesClient = grpc.ClientStreamingClient[RecipeImportRow, RecipeImportResult]
+
+func (c *recipeServiceClient) ExportRecipes(ctx context.Context, in *RecipeExportRequest, opts ...grpc.CallOption) (*RecipeExport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecipeExport)
+	err := c.cc.Invoke(ctx, RecipeService_ExportRecipes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) SearchRecipes(ctx context.Context, in *RecipeSearchRequest, opts ...grpc.CallOption) (*RecipeSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecipeSearchResponse)
+	err := c.cc.Invoke(ctx, RecipeService_SearchRecipes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) CheckAllergens(ctx context.Context, in *AllergenCheckRequest, opts ...grpc.CallOption) (*AllergenReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllergenReport)
+	err := c.cc.Invoke(ctx, RecipeService_CheckAllergens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecipeServiceServer is the server API for RecipeService service.
+// All implementations must embed UnimplementedRecipeServiceServer
+// for forward compatibility.
+type RecipeServiceServer interface {
+	CreateRecipe(context.Context, *Recipe) (*RecipeID, error)
+	GetRecipe(context.Context, *RecipeID) (*Recipe, error)
+	UpdateRecipe(context.Context, *Recipe) (*Recipe, error)
+	DeleteRecipe(context.Context, *RecipeID) (*emptypb.Empty, error)
+	RestoreRecipe(context.Context, *RecipeID) (*Recipe, error)
+	ListRecipes(context.Context, *RecipeQuery) (*RecipeList, error)
+	ImportRecipes(grpc.ClientStreamingServer[RecipeImportRow, RecipeImportResult]) error
+	ExportRecipes(context.Context, *RecipeExportRequest) (*RecipeExport, error)
+	SearchRecipes(context.Context, *RecipeSearchRequest) (*RecipeSearchResponse, error)
+	CheckAllergens(context.Context, *AllergenCheckRequest) (*AllergenReport, error)
+	mustEmbedUnimplementedRecipeServiceServer()
+}
+
+// UnimplementedRecipeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRecipeServiceServer struct{}
+
+func (UnimplementedRecipeServiceServer) CreateRecipe(context.Context, *Recipe) (*RecipeID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) GetRecipe(context.Context, *RecipeID) (*Recipe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) UpdateRecipe(context.Context, *Recipe) (*Recipe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) DeleteRecipe(context.Context, *RecipeID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) RestoreRecipe(context.Context, *RecipeID) (*Recipe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) ListRecipes(context.Context, *RecipeQuery) (*RecipeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecipes not implemented")
+}
+func (UnimplementedRecipeServiceServer) ImportRecipes(grpc.ClientStreamingServer[RecipeImportRow, RecipeImportResult]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportRecipes not implemented")
+}
+func (UnimplementedRecipeServiceServer) ExportRecipes(context.Context, *RecipeExportRequest) (*RecipeExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportRecipes not implemented")
+}
+func (UnimplementedRecipeServiceServer) SearchRecipes(context.Context, *RecipeSearchRequest) (*RecipeSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchRecipes not implemented")
+}
+func (UnimplementedRecipeServiceServer) CheckAllergens(context.Context, *AllergenCheckRequest) (*AllergenReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckAllergens not implemented")
+}
+func (UnimplementedRecipeServiceServer) mustEmbedUnimplementedRecipeServiceServer() {}
+func (UnimplementedRecipeServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeRecipeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecipeServiceServer will
+// result in compilation errors.
+type UnsafeRecipeServiceServer interface {
+	mustEmbedUnimplementedRecipeServiceServer()
+}
+
+func RegisterRecipeServiceServer(s grpc.ServiceRegistrar, srv RecipeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRecipeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RecipeService_ServiceDesc, srv)
+}
+
+func _RecipeService_CreateRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Recipe)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).CreateRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_CreateRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).CreateRecipe(ctx, req.(*Recipe))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_GetRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecipeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).GetRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_GetRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).GetRecipe(ctx, req.(*RecipeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_UpdateRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Recipe)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).UpdateRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_UpdateRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).UpdateRecipe(ctx, req.(*Recipe))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_DeleteRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecipeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).DeleteRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_DeleteRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).DeleteRecipe(ctx, req.(*RecipeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_RestoreRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecipeID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).RestoreRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_RestoreRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).RestoreRecipe(ctx, req.(*RecipeID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_ListRecipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecipeQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).ListRecipes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_ListRecipes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).ListRecipes(ctx, req.(*RecipeQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_ImportRecipes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RecipeServiceServer).ImportRecipes(&grpc.GenericServerStream[RecipeImportRow, RecipeImportResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RecipeService_ImportRecipesServer = grpc.ClientStreamingServer[RecipeImportRow, RecipeImportResult]
+
+func _RecipeService_ExportRecipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecipeExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).ExportRecipes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_ExportRecipes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).ExportRecipes(ctx, req.(*RecipeExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_SearchRecipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecipeSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).SearchRecipes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_SearchRecipes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).SearchRecipes(ctx, req.(*RecipeSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_CheckAllergens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllergenCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).CheckAllergens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_CheckAllergens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).CheckAllergens(ctx, req.(*AllergenCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RecipeService_ServiceDesc is the grpc.ServiceDesc for RecipeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var RecipeService_ServiceDesc = grpc.ServiceDesc{
@@ -392,24 +1684,64 @@ var RecipeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateRecipe",
 			Handler:    _RecipeService_CreateRecipe_Handler,
 		},
+		{
+			MethodName: "GetRecipe",
+			Handler:    _RecipeService_GetRecipe_Handler,
+		},
+		{
+			MethodName: "UpdateRecipe",
+			Handler:    _RecipeService_UpdateRecipe_Handler,
+		},
+		{
+			MethodName: "DeleteRecipe",
+			Handler:    _RecipeService_DeleteRecipe_Handler,
+		},
+		{
+			MethodName: "RestoreRecipe",
+			Handler:    _RecipeService_RestoreRecipe_Handler,
+		},
 		{
 			MethodName: "ListRecipes",
 			Handler:    _RecipeService_ListRecipes_Handler,
 		},
+		{
+			MethodName: "ExportRecipes",
+			Handler:    _RecipeService_ExportRecipes_Handler,
+		},
+		{
+			MethodName: "SearchRecipes",
+			Handler:    _RecipeService_SearchRecipes_Handler,
+		},
+		{
+			MethodName: "CheckAllergens",
+			Handler:    _RecipeService_CheckAllergens_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportRecipes",
+			Handler:       _RecipeService_ImportRecipes_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/spiceroute.proto",
 }
 
 const (
-	FeedbackService_SubmitFeedback_FullMethodName = "/spiceroute.v1.FeedbackService/SubmitFeedback"
+	FeedbackService_SubmitFeedback_FullMethodName         = "/spiceroute.v1.FeedbackService/SubmitFeedback"
+	FeedbackService_ListFeedbackByUser_FullMethodName     = "/spiceroute.v1.FeedbackService/ListFeedbackByUser"
+	FeedbackService_ListFeedbackByRecipe_FullMethodName   = "/spiceroute.v1.FeedbackService/ListFeedbackByRecipe"
+	FeedbackService_GetRecipeRatingSummary_FullMethodName = "/spiceroute.v1.FeedbackService/GetRecipeRatingSummary"
 )
 
 // FeedbackServiceClient is the client API for FeedbackService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type FeedbackServiceClient interface {
-	SubmitFeedback(ctx context.Context, in *FeedbackBatch, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SubmitFeedback(ctx context.Context, in *FeedbackBatch, opts ...grpc.CallOption) (*FeedbackBatchResult, error)
+	ListFeedbackByUser(ctx context.Context, in *FeedbackQuery, opts ...grpc.CallOption) (*FeedbackPage, error)
+	ListFeedbackByRecipe(ctx context.Context, in *FeedbackQuery, opts ...grpc.CallOption) (*FeedbackPage, error)
+	GetRecipeRatingSummary(ctx context.Context, in *RatingSummaryRequest, opts ...grpc.CallOption) (*RatingSummary, error)
 }
 
 type feedbackServiceClient struct {
@@ -420,9 +1752,9 @@ func NewFeedbackServiceClient(cc grpc.ClientConnInterface) FeedbackServiceClient
 	return &feedbackServiceClient{cc}
 }
 
-func (c *feedbackServiceClient) SubmitFeedback(ctx context.Context, in *FeedbackBatch, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *feedbackServiceClient) SubmitFeedback(ctx context.Context, in *FeedbackBatch, opts ...grpc.CallOption) (*FeedbackBatchResult, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(FeedbackBatchResult)
 	err := c.cc.Invoke(ctx, FeedbackService_SubmitFeedback_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -430,11 +1762,44 @@ func (c *feedbackServiceClient) SubmitFeedback(ctx context.Context, in *Feedback
 	return out, nil
 }
 
+func (c *feedbackServiceClient) ListFeedbackByUser(ctx context.Context, in *FeedbackQuery, opts ...grpc.CallOption) (*FeedbackPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeedbackPage)
+	err := c.cc.Invoke(ctx, FeedbackService_ListFeedbackByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedbackServiceClient) ListFeedbackByRecipe(ctx context.Context, in *FeedbackQuery, opts ...grpc.CallOption) (*FeedbackPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeedbackPage)
+	err := c.cc.Invoke(ctx, FeedbackService_ListFeedbackByRecipe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *feedbackServiceClient) GetRecipeRatingSummary(ctx context.Context, in *RatingSummaryRequest, opts ...grpc.CallOption) (*RatingSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RatingSummary)
+	err := c.cc.Invoke(ctx, FeedbackService_GetRecipeRatingSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FeedbackServiceServer is the server API for FeedbackService service.
 // All implementations must embed UnimplementedFeedbackServiceServer
 // for forward compatibility.
 type FeedbackServiceServer interface {
-	SubmitFeedback(context.Context, *FeedbackBatch) (*emptypb.Empty, error)
+	SubmitFeedback(context.Context, *FeedbackBatch) (*FeedbackBatchResult, error)
+	ListFeedbackByUser(context.Context, *FeedbackQuery) (*FeedbackPage, error)
+	ListFeedbackByRecipe(context.Context, *FeedbackQuery) (*FeedbackPage, error)
+	GetRecipeRatingSummary(context.Context, *RatingSummaryRequest) (*RatingSummary, error)
 	mustEmbedUnimplementedFeedbackServiceServer()
 }
 
@@ -445,9 +1810,18 @@ type FeedbackServiceServer interface {
 // pointer dereference when methods are called.
 type UnimplementedFeedbackServiceServer struct{}
 
-func (UnimplementedFeedbackServiceServer) SubmitFeedback(context.Context, *FeedbackBatch) (*emptypb.Empty, error) {
+func (UnimplementedFeedbackServiceServer) SubmitFeedback(context.Context, *FeedbackBatch) (*FeedbackBatchResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitFeedback not implemented")
 }
+func (UnimplementedFeedbackServiceServer) ListFeedbackByUser(context.Context, *FeedbackQuery) (*FeedbackPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeedbackByUser not implemented")
+}
+func (UnimplementedFeedbackServiceServer) ListFeedbackByRecipe(context.Context, *FeedbackQuery) (*FeedbackPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeedbackByRecipe not implemented")
+}
+func (UnimplementedFeedbackServiceServer) GetRecipeRatingSummary(context.Context, *RatingSummaryRequest) (*RatingSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecipeRatingSummary not implemented")
+}
 func (UnimplementedFeedbackServiceServer) mustEmbedUnimplementedFeedbackServiceServer() {}
 func (UnimplementedFeedbackServiceServer) testEmbeddedByValue()                         {}
 
@@ -487,6 +1861,60 @@ func _FeedbackService_SubmitFeedback_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FeedbackService_ListFeedbackByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeedbackQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedbackServiceServer).ListFeedbackByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedbackService_ListFeedbackByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedbackServiceServer).ListFeedbackByUser(ctx, req.(*FeedbackQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedbackService_ListFeedbackByRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeedbackQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedbackServiceServer).ListFeedbackByRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedbackService_ListFeedbackByRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedbackServiceServer).ListFeedbackByRecipe(ctx, req.(*FeedbackQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeedbackService_GetRecipeRatingSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RatingSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeedbackServiceServer).GetRecipeRatingSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeedbackService_GetRecipeRatingSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeedbackServiceServer).GetRecipeRatingSummary(ctx, req.(*RatingSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FeedbackService_ServiceDesc is the grpc.ServiceDesc for FeedbackService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -498,6 +1926,196 @@ var FeedbackService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitFeedback",
 			Handler:    _FeedbackService_SubmitFeedback_Handler,
 		},
+		{
+			MethodName: "ListFeedbackByUser",
+			Handler:    _FeedbackService_ListFeedbackByUser_Handler,
+		},
+		{
+			MethodName: "ListFeedbackByRecipe",
+			Handler:    _FeedbackService_ListFeedbackByRecipe_Handler,
+		},
+		{
+			MethodName: "GetRecipeRatingSummary",
+			Handler:    _FeedbackService_GetRecipeRatingSummary_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/spiceroute.proto",
+}
+
+const (
+	VectorService_SearchSimilar_FullMethodName = "/spiceroute.v1.VectorService/SearchSimilar"
+	VectorService_Recommend_FullMethodName     = "/spiceroute.v1.VectorService/Recommend"
+	VectorService_Reindex_FullMethodName       = "/spiceroute.v1.VectorService/Reindex"
+)
+
+// VectorServiceClient is the client API for VectorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VectorServiceClient interface {
+	SearchSimilar(ctx context.Context, in *SemanticSearchRequest, opts ...grpc.CallOption) (*SimilarRecipes, error)
+	Recommend(ctx context.Context, in *RecommendationRequest, opts ...grpc.CallOption) (*SimilarRecipes, error)
+	Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*VectorIndexStatus, error)
+}
+
+type vectorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVectorServiceClient(cc grpc.ClientConnInterface) VectorServiceClient {
+	return &vectorServiceClient{cc}
+}
+
+func (c *vectorServiceClient) SearchSimilar(ctx context.Context, in *SemanticSearchRequest, opts ...grpc.CallOption) (*SimilarRecipes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimilarRecipes)
+	err := c.cc.Invoke(ctx, VectorService_SearchSimilar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorServiceClient) Recommend(ctx context.Context, in *RecommendationRequest, opts ...grpc.CallOption) (*SimilarRecipes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimilarRecipes)
+	err := c.cc.Invoke(ctx, VectorService_Recommend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vectorServiceClient) Reindex(ctx context.Context, in *ReindexRequest, opts ...grpc.CallOption) (*VectorIndexStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VectorIndexStatus)
+	err := c.cc.Invoke(ctx, VectorService_Reindex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VectorServiceServer is the server API for VectorService service.
+// All implementations must embed UnimplementedVectorServiceServer
+// for forward compatibility.
+type VectorServiceServer interface {
+	SearchSimilar(context.Context, *SemanticSearchRequest) (*SimilarRecipes, error)
+	Recommend(context.Context, *RecommendationRequest) (*SimilarRecipes, error)
+	Reindex(context.Context, *ReindexRequest) (*VectorIndexStatus, error)
+	mustEmbedUnimplementedVectorServiceServer()
+}
+
+// UnimplementedVectorServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVectorServiceServer struct{}
+
+func (UnimplementedVectorServiceServer) SearchSimilar(context.Context, *SemanticSearchRequest) (*SimilarRecipes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSimilar not implemented")
+}
+func (UnimplementedVectorServiceServer) Recommend(context.Context, *RecommendationRequest) (*SimilarRecipes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recommend not implemented")
+}
+func (UnimplementedVectorServiceServer) Reindex(context.Context, *ReindexRequest) (*VectorIndexStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reindex not implemented")
+}
+func (UnimplementedVectorServiceServer) mustEmbedUnimplementedVectorServiceServer() {}
+func (UnimplementedVectorServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeVectorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VectorServiceServer will
+// result in compilation errors.
+type UnsafeVectorServiceServer interface {
+	mustEmbedUnimplementedVectorServiceServer()
+}
+
+func RegisterVectorServiceServer(s grpc.ServiceRegistrar, srv VectorServiceServer) {
+	// If the following call pancis, it indicates UnimplementedVectorServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VectorService_ServiceDesc, srv)
+}
+
+func _VectorService_SearchSimilar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SemanticSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorServiceServer).SearchSimilar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorService_SearchSimilar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorServiceServer).SearchSimilar(ctx, req.(*SemanticSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorService_Recommend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecommendationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorServiceServer).Recommend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorService_Recommend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorServiceServer).Recommend(ctx, req.(*RecommendationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VectorService_Reindex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VectorServiceServer).Reindex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VectorService_Reindex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VectorServiceServer).Reindex(ctx, req.(*ReindexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VectorService_ServiceDesc is the grpc.ServiceDesc for VectorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VectorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spiceroute.v1.VectorService",
+	HandlerType: (*VectorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchSimilar",
+			Handler:    _VectorService_SearchSimilar_Handler,
+		},
+		{
+			MethodName: "Recommend",
+			Handler:    _VectorService_Recommend_Handler,
+		},
+		{
+			MethodName: "Reindex",
+			Handler:    _VectorService_Reindex_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/spiceroute.proto",
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pb "spiceroute/proto"
+)
+
+// readJSONLines reads one recipe object per line. Blank lines are skipped.
+func readJSONLines(r io.Reader, source string) ([]record, error) {
+	var records []record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		rec := record{source: source, row: line}
+		var recipe pb.Recipe
+		if err := json.Unmarshal([]byte(text), &recipe); err != nil {
+			rec.err = fmt.Errorf("invalid JSON: %w", err)
+		} else {
+			rec.recipe = &recipe
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// readCSV reads recipes from rows under a header of Recipe field names.
+// Unknown columns are an error so a misspelt header is not silently lost.
+func readCSV(r io.Reader, source string) ([]record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header row: %w", err)
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+		if !csvColumns[header[i]] {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+	}
+
+	var records []record
+	for row := 2; ; row++ {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		rec := record{source: source, row: row}
+		if err != nil {
+			rec.err = err
+		} else {
+			rec.recipe, rec.err = recipeFromCSV(header, fields)
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+var csvColumns = map[string]bool{
+	"name": true, "cuisine": true, "prep_minutes": true, "calories": true,
+	"ingredients": true, "cost": true, "shelf_life_days": true, "tags": true,
+	"nutrition": true,
+}
+
+func recipeFromCSV(header, fields []string) (*pb.Recipe, error) {
+	if len(fields) > len(header) {
+		return nil, fmt.Errorf("row has %d fields, header has %d", len(fields), len(header))
+	}
+
+	recipe := &pb.Recipe{}
+	for i, value := range fields {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		var err error
+		switch header[i] {
+		case "name":
+			recipe.Name = value
+		case "cuisine":
+			recipe.Cuisine = value
+		case "nutrition":
+			recipe.Nutrition = value
+		case "ingredients":
+			recipe.Ingredients = splitList(value)
+		case "tags":
+			recipe.Tags = splitList(value)
+		case "prep_minutes":
+			recipe.PrepMinutes, err = parseInt32(value)
+		case "calories":
+			recipe.Calories, err = parseInt32(value)
+		case "shelf_life_days":
+			recipe.ShelfLifeDays, err = parseInt32(value)
+		case "cost":
+			recipe.Cost, err = strconv.ParseFloat(value, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", header[i], value)
+		}
+	}
+	return recipe, nil
+}
+
+// splitList splits a ";"-separated cell, dropping blank items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseInt32(value string) (int32, error) {
+	n, err := strconv.ParseInt(value, 10, 32)
+	return int32(n), err
+}
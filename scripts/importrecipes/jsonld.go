@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pb "spiceroute/proto"
+)
+
+var (
+	// isoDuration matches the ISO 8601 durations schema.org uses, e.g. PT1H30M
+	isoDuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+	leadingNum  = regexp.MustCompile(`\d+(?:\.\d+)?`)
+)
+
+// readJSONLD reads every schema.org Recipe in a JSON-LD document, which may
+// be a single node, an array of nodes or a graph. Recipes nested in other
+// nodes, such as a page's mainEntity, are found too. Rows are numbered by
+// the recipe's position in the document.
+func readJSONLD(r io.Reader, source string) ([]record, error) {
+	var doc interface{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var records []record
+	for i, node := range findRecipes(doc) {
+		rec := record{source: source, row: i + 1}
+		rec.recipe, rec.err = recipeFromJSONLD(node)
+		records = append(records, rec)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no schema.org Recipe found")
+	}
+	return records, nil
+}
+
+// findRecipes walks a JSON-LD value and returns the nodes typed Recipe
+func findRecipes(v interface{}) []map[string]interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		var found []map[string]interface{}
+		for _, item := range v {
+			found = append(found, findRecipes(item)...)
+		}
+		return found
+	case map[string]interface{}:
+		for _, t := range textList(v["@type"]) {
+			if t == "Recipe" || t == "schema:Recipe" || strings.HasSuffix(t, "schema.org/Recipe") {
+				return []map[string]interface{}{v}
+			}
+		}
+		var found []map[string]interface{}
+		for key, value := range v {
+			if key != "@context" {
+				found = append(found, findRecipes(value)...)
+			}
+		}
+		return found
+	}
+	return nil
+}
+
+func recipeFromJSONLD(node map[string]interface{}) (*pb.Recipe, error) {
+	recipe := &pb.Recipe{
+		Name:        text(node["name"]),
+		Cuisine:     text(node["recipeCuisine"]),
+		Ingredients: textList(node["recipeIngredient"]),
+	}
+	if len(recipe.Ingredients) == 0 {
+		// The pre-2015 property name, still common in the wild
+		recipe.Ingredients = textList(node["ingredients"])
+	}
+
+	prep, err := minutes(node["totalTime"])
+	if err != nil {
+		return nil, err
+	}
+	if prep == 0 {
+		p, err := minutes(node["prepTime"])
+		if err != nil {
+			return nil, err
+		}
+		c, err := minutes(node["cookTime"])
+		if err != nil {
+			return nil, err
+		}
+		prep = p + c
+	}
+	recipe.PrepMinutes = prep
+
+	for _, keyword := range append(textList(node["keywords"]), textList(node["recipeCategory"])...) {
+		for _, tag := range strings.Split(keyword, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				recipe.Tags = append(recipe.Tags, tag)
+			}
+		}
+	}
+
+	if cost := node["estimatedCost"]; cost != nil {
+		if m, ok := cost.(map[string]interface{}); ok {
+			cost = m["value"]
+		}
+		recipe.Cost = number(cost)
+	}
+
+	servings := int32(number(node["recipeYield"]))
+	if facts, ok := node["nutrition"].(map[string]interface{}); ok {
+		recipe.Calories = int32(math.Round(number(facts["calories"])))
+		recipe.Nutrition = nutritionLabel(facts, servings)
+	}
+	return recipe, nil
+}
+
+// nutritionLabel renders NutritionInformation as the free-text label the
+// recipes service parses into structured facts
+func nutritionLabel(facts map[string]interface{}, servings int32) string {
+	var parts []string
+	for _, n := range []struct{ property, name, unit string }{
+		{"proteinContent", "protein", "g"},
+		{"carbohydrateContent", "carbs", "g"},
+		{"fatContent", "fat", "g"},
+		{"fiberContent", "fiber", "g"},
+		{"sodiumContent", "sodium", "mg"},
+		{"sugarContent", "sugar", "g"},
+	} {
+		value := text(facts[n.property])
+		if amount := leadingNum.FindString(value); amount != "" {
+			unit := n.unit
+			if strings.Contains(strings.ToLower(value), "mg") {
+				unit = "mg"
+			} else if strings.Contains(value, "g") {
+				unit = "g"
+			}
+			parts = append(parts, n.name+" "+amount+" "+unit)
+		}
+	}
+	if len(parts) > 0 && servings > 0 {
+		parts = append(parts, fmt.Sprintf("serves %d", servings))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// minutes converts an ISO 8601 duration to whole minutes
+func minutes(v interface{}) (int32, error) {
+	s := strings.TrimSpace(text(v))
+	if s == "" {
+		return 0, nil
+	}
+	m := isoDuration.FindStringSubmatch(strings.ToUpper(s))
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var total float64
+	for i, scale := range []float64{24 * 60, 60, 1, 1.0 / 60} {
+		if m[i+1] != "" {
+			n, _ := strconv.ParseFloat(m[i+1], 64)
+			total += n * scale
+		}
+	}
+	return int32(math.Round(total)), nil
+}
+
+// text returns the text of a JSON-LD value: a string, a number, the first
+// item of a list, or the name or @value of a node
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		if len(v) > 0 {
+			return text(v[0])
+		}
+	case map[string]interface{}:
+		if name, ok := v["name"]; ok {
+			return text(name)
+		}
+		if value, ok := v["@value"]; ok {
+			return text(value)
+		}
+		return text(v["text"])
+	}
+	return ""
+}
+
+// textList returns the texts of a JSON-LD value that may be one item or a list
+func textList(v interface{}) []string {
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+	var out []string
+	for _, item := range items {
+		if s := text(item); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// number returns the first number in a JSON-LD value such as "250 calories"
+func number(v interface{}) float64 {
+	n, _ := strconv.ParseFloat(leadingNum.FindString(text(v)), 64)
+	return n
+}
@@ -0,0 +1,134 @@
+// Command importrecipes bulk loads recipes into the recipes service from
+// JSON Lines, CSV and schema.org Recipe JSON-LD files.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"spiceroute/pkg/config"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const usage = `usage: go run ./scripts/importrecipes [-dry-run] file...
+
+Files are read by extension:
+  .jsonl, .ndjson  one recipe object per line, using the Recipe field names
+  .csv             a header row naming Recipe fields; list fields
+                   (ingredients, tags) separate their items with ";"
+  .json, .jsonld   schema.org Recipe JSON-LD: one object, an array or an @graph`
+
+// record is one recipe read from a file, or the reason it could not be read
+type record struct {
+	source string
+	row    int
+	recipe *pb.Recipe
+	err    error
+}
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "parse the files and report errors without importing")
+	flag.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var records []record
+	for _, path := range flag.Args() {
+		parsed, err := readFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", path, err)
+		}
+		records = append(records, parsed...)
+	}
+
+	parseErrors := 0
+	for _, r := range records {
+		if r.err != nil {
+			parseErrors++
+			fmt.Fprintf(os.Stderr, "%s:%d: %v\n", r.source, r.row, r.err)
+		}
+	}
+	if *dryRun {
+		fmt.Printf("parsed %d recipes, %d rows could not be read\n", len(records)-parseErrors, parseErrors)
+		exit(parseErrors)
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+	conn, err := grpc.NewClient(cfg.Address("recipes"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatal("Failed to create recipes client:", err)
+	}
+	defer conn.Close()
+
+	result, err := send(context.Background(), pb.NewRecipeServiceClient(conn), records)
+	if err != nil {
+		log.Fatal("Import failed:", err)
+	}
+
+	for _, e := range result.Errors {
+		fmt.Fprintf(os.Stderr, "%s:%d: %s: %s\n", e.Source, e.Row, e.Name, e.Error)
+	}
+	fmt.Printf("received %d, created %d, duplicates %d, failed %d, unreadable %d\n",
+		result.Received, result.Created, result.Duplicates, result.Failed, parseErrors)
+	exit(parseErrors + int(result.Failed))
+}
+
+// send streams the readable records to ImportRecipes
+func send(ctx context.Context, client pb.RecipeServiceClient, records []record) (*pb.RecipeImportResult, error) {
+	stream, err := client.ImportRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		if r.err != nil {
+			continue
+		}
+		row := &pb.RecipeImportRow{Source: r.source, Row: int32(r.row), Recipe: r.recipe}
+		if err := stream.Send(row); err != nil {
+			// The server ended the stream; CloseAndRecv reports why
+			break
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// readFile parses path according to its extension
+func readFile(path string) ([]record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	source := filepath.Base(path)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return readJSONLines(f, source)
+	case ".csv":
+		return readCSV(f, source)
+	case ".json", ".jsonld":
+		return readJSONLD(f, source)
+	}
+	return nil, fmt.Errorf("unsupported file type %q", filepath.Ext(path))
+}
+
+func exit(failures int) {
+	if failures > 0 {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
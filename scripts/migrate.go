@@ -1,28 +1,80 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
 
+	"spiceroute/pkg/config"
 	"spiceroute/pkg/database"
 )
 
+const usage = `usage: go run scripts/migrate.go [command]
+
+commands:
+  up        apply all pending migrations (default)
+  down [n]  revert the last n applied migrations (default 1)
+  redo      revert and re-apply the last applied migration
+  status    list migrations and whether each has been applied`
+
 func main() {
-	// Check if DB_DSN is set
-	if os.Getenv("DB_DSN") == "" {
-		log.Fatal("DB_DSN environment variable is required")
+	command := "up"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
 	}
 
 	// Initialize database connection
-	db, err := database.NewConnection()
+	db, err := database.NewConnection(cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
+	defer database.CloseConnection(db)
 
-	// Run migrations
-	if err := database.AutoMigrate(db); err != nil {
-		log.Fatal("Failed to run migrations:", err)
-	}
+	switch command {
+	case "up":
+		if err := database.MigrateUp(db); err != nil {
+			log.Fatal("Failed to run migrations:", err)
+		}
+		log.Println("Database migrations completed successfully!")
+
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				log.Fatalf("Invalid step count %q", os.Args[2])
+			}
+		}
+		if err := database.MigrateDown(db, steps); err != nil {
+			log.Fatal("Failed to revert migrations:", err)
+		}
+
+	case "redo":
+		if err := database.Redo(db); err != nil {
+			log.Fatal("Failed to redo migration:", err)
+		}
 
-	log.Println("Database migrations completed successfully!")
+	case "status":
+		statuses, err := database.Status(db)
+		if err != nil {
+			log.Fatal("Failed to read migration status:", err)
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.Applied {
+				applied = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%4d  %-32s %s\n", s.Version, s.Name, applied)
+		}
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
 }
@@ -2,80 +2,123 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
-	"net"
-	"time"
 
+	"spiceroute/pkg/config"
 	"spiceroute/pkg/database"
+	"spiceroute/pkg/grpcserver"
 	"spiceroute/pkg/models"
 	pb "spiceroute/proto"
 
-	"google.golang.org/grpc"
-	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
 type server struct {
 	db *gorm.DB
+	// reader serves read-heavy queries that tolerate replication lag
+	reader *gorm.DB
 	pb.UnimplementedFeedbackServiceServer
 }
 
-func (s *server) SubmitFeedback(ctx context.Context, batch *pb.FeedbackBatch) (*emptypb.Empty, error) {
-	// Use a transaction for batch operations
-	return &emptypb.Empty{}, s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for _, f := range batch.Entries {
-			// Parse cooked_at time
-			cookedAt, err := time.Parse(time.RFC3339, f.CookedAt)
-			if err != nil {
-				// If parsing fails, use current time
-				cookedAt = time.Now()
-			}
+// SubmitFeedback validates and stores a batch of feedback, reporting the
+// outcome of every entry. Unless the batch allows partial ingestion, one
+// rejected entry keeps the whole batch out.
+func (s *server) SubmitFeedback(ctx context.Context, batch *pb.FeedbackBatch) (*pb.FeedbackBatchResult, error) {
+	if len(batch.Entries) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "batch has no entries")
+	}
 
-			feedback := models.Feedback{
-				UserID:          f.UserId,
-				DishID:          f.DishId,
-				Rating:          f.Rating,
-				Skipped:         f.Skipped,
-				SubstitutedWith: f.SubstitutedWith,
-				Comment:         f.Comment,
-				CookedAt:        cookedAt,
-			}
+	results, valid, err := s.validateBatch(ctx, batch.Entries)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.FeedbackBatchResult{Results: results}
 
-			// Use Upsert (Create or Update) based on user_id, dish_id, and cooked_at
-			result := tx.Where("user_id = ? AND dish_id = ? AND cooked_at = ?",
-				f.UserId, f.DishId, cookedAt).
-				Assign(feedback).
-				FirstOrCreate(&feedback)
+	if len(valid) < len(batch.Entries) && !batch.AllowPartial {
+		for _, v := range valid {
+			results[v.index].Reasons = append(results[v.index].Reasons, "not stored because other entries in the batch were rejected")
+		}
+		resp.Rejected = int32(len(batch.Entries))
+		return resp, nil
+	}
 
-			if result.Error != nil {
-				return result.Error
+	// Use a transaction for batch operations
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range valid {
+			v := &valid[i]
+			// Each entry gets a savepoint so a partial batch can skip
+			// entries the database refuses
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				return upsertFeedback(tx, &v.feedback)
+			})
+			if err != nil {
+				if !batch.AllowPartial {
+					return fmt.Errorf("entry %d: %w", v.index, err)
+				}
+				log.Printf("Failed to store feedback entry %d: %v", v.index, err)
+				results[v.index].Reasons = append(results[v.index].Reasons, "could not be stored")
+				continue
 			}
+
+			results[v.index].Accepted = true
+			results[v.index].Id = uint64(v.feedback.ID)
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range results {
+		if r.Accepted {
+			resp.Accepted++
+		} else {
+			resp.Rejected++
+		}
+	}
+	return resp, nil
+}
+
+// upsertFeedback creates a feedback entry or updates the one recorded for
+// the same user, dish and cooked_at
+func upsertFeedback(tx *gorm.DB, feedback *models.Feedback) error {
+	return tx.Where("user_id = ? AND dish_id = ? AND cooked_at = ?",
+		feedback.UserID, feedback.DishID, feedback.CookedAt).
+		Assign(*feedback).
+		FirstOrCreate(feedback).Error
 }
 
 func main() {
+	cfg, err := config.Load("feedback")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
 	// Initialize database connection
-	db, err := database.NewConnection()
+	db, err := database.NewConnection(cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	// Run migrations
-	if err := database.AutoMigrate(db); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+	// Refuse to start against a schema older than this build
+	if err := database.VerifySchema(db); err != nil {
+		log.Fatal("Database schema check failed:", err)
 	}
 
-	// Start gRPC server
-	lis, err := net.Listen("tcp", ":50054")
+	reader, err := database.NewReadConnection(cfg.Database, db)
 	if err != nil {
-		log.Fatal("Failed to listen:", err)
+		log.Fatal("Failed to connect to read replica:", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterFeedbackServiceServer(grpcServer, &server{db: db})
+	// Start gRPC server
+	grpcServer := grpcserver.NewServer()
+	pb.RegisterFeedbackServiceServer(grpcServer, &server{db: db, reader: reader})
 
-	log.Println("Feedback service starting on :50054")
-	log.Fatal(grpcServer.Serve(lis))
+	log.Printf("Feedback service starting on %s", cfg.ListenAddr())
+	if err := grpcserver.Serve(grpcServer, cfg, db); err != nil {
+		log.Fatal("Failed to serve:", err)
+	}
 }
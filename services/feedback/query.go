@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+	// topSubstitutions is how many substitutions a rating summary lists
+	topSubstitutions = 5
+)
+
+// pageCursor is the decoded form of the opaque page token. Feedback is
+// listed newest first, so the cursor holds the cooked_at and id of the last
+// entry on the previous page.
+type pageCursor struct {
+	CookedAt time.Time `json:"t"`
+	ID       uint      `json:"id"`
+}
+
+func (c pageCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageCursor(token string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("malformed page token")
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == 0 {
+		return c, fmt.Errorf("malformed page token")
+	}
+	return c, nil
+}
+
+func (s *server) ListFeedbackByUser(ctx context.Context, q *pb.FeedbackQuery) (*pb.FeedbackPage, error) {
+	if q.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	return s.listFeedback(ctx, s.reader.WithContext(ctx).Where("user_id = ?", q.UserId), q)
+}
+
+func (s *server) ListFeedbackByRecipe(ctx context.Context, q *pb.FeedbackQuery) (*pb.FeedbackPage, error) {
+	if q.RecipeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipe id is required")
+	}
+	return s.listFeedback(ctx, s.reader.WithContext(ctx).Where("dish_id = ?", q.RecipeId), q)
+}
+
+// listFeedback pages through the feedback matched by query, newest first,
+// within the date range of q
+func (s *server) listFeedback(ctx context.Context, query *gorm.DB, q *pb.FeedbackQuery) (*pb.FeedbackPage, error) {
+	query, err := applyDateRange(query, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.PageToken != "" {
+		cursor, err := decodePageCursor(q.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		query = query.Where("(cooked_at, id) < (?, ?)", cursor.CookedAt, cursor.ID)
+	}
+
+	pageSize := defaultPageSize
+	if q.PageSize > 0 {
+		pageSize = min(int(q.PageSize), maxPageSize)
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	var entries []models.Feedback
+	result := query.Order("cooked_at DESC, id DESC").Limit(pageSize + 1).Find(&entries)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	page := &pb.FeedbackPage{}
+	if len(entries) > pageSize {
+		entries = entries[:pageSize]
+		last := entries[pageSize-1]
+		page.NextPageToken = pageCursor{CookedAt: last.CookedAt, ID: last.ID}.encode()
+	}
+	for _, f := range entries {
+		page.Entries = append(page.Entries, feedbackToProto(f))
+	}
+	return page, nil
+}
+
+// GetRecipeRatingSummary aggregates a recipe's feedback into its average
+// rating, rating histogram, skip rate and most common substitutions
+func (s *server) GetRecipeRatingSummary(ctx context.Context, req *pb.RatingSummaryRequest) (*pb.RatingSummary, error) {
+	if req.RecipeId == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipe id is required")
+	}
+
+	// scoped returns a fresh query over the recipe's feedback in range
+	scoped := func() (*gorm.DB, error) {
+		query := s.reader.WithContext(ctx).Model(&models.Feedback{}).Where("dish_id = ?", req.RecipeId)
+		return applyDateRange(query, req.From, req.To)
+	}
+
+	query, err := scoped()
+	if err != nil {
+		return nil, err
+	}
+	var totals struct {
+		Total   int64
+		Skipped int64
+	}
+	result := query.Select("COUNT(*) AS total, COUNT(*) FILTER (WHERE skipped) AS skipped").Scan(&totals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	query, _ = scoped()
+	var buckets []struct {
+		Rating int32
+		Count  int64
+	}
+	result = query.Select("rating, COUNT(*) AS count").
+		Where("rating BETWEEN 1 AND 5").
+		Group("rating").
+		Scan(&buckets)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	query, _ = scoped()
+	var substitutions []struct {
+		SubstitutedWith string
+		Count           int64
+	}
+	result = query.Select("substituted_with, COUNT(*) AS count").
+		Where("substituted_with <> ''").
+		Group("substituted_with").
+		Order("count DESC, substituted_with").
+		Limit(topSubstitutions).
+		Scan(&substitutions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	summary := &pb.RatingSummary{RecipeId: req.RecipeId, Total: totals.Total}
+	counts := make(map[int32]int64, len(buckets))
+	var sum int64
+	for _, b := range buckets {
+		counts[b.Rating] = b.Count
+		summary.Rated += b.Count
+		sum += int64(b.Rating) * b.Count
+	}
+	for rating := int32(1); rating <= 5; rating++ {
+		summary.Histogram = append(summary.Histogram, &pb.RatingBucket{Rating: rating, Count: counts[rating]})
+	}
+	if summary.Rated > 0 {
+		summary.AverageRating = math.Round(float64(sum)/float64(summary.Rated)*100) / 100
+	}
+	if totals.Total > 0 {
+		summary.SkipRate = math.Round(float64(totals.Skipped)/float64(totals.Total)*1000) / 1000
+	}
+	for _, sub := range substitutions {
+		summary.TopSubstitutions = append(summary.TopSubstitutions, &pb.SubstitutionCount{
+			SubstitutedWith: sub.SubstitutedWith,
+			Count:           sub.Count,
+		})
+	}
+
+	return summary, nil
+}
+
+// applyDateRange limits query to feedback cooked in [from, to). Either
+// bound may be empty.
+func applyDateRange(query *gorm.DB, from, to string) (*gorm.DB, error) {
+	var start, end time.Time
+	var err error
+	if from != "" {
+		if start, err = time.Parse(time.RFC3339, from); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid from %q: %v", from, err)
+		}
+		query = query.Where("cooked_at >= ?", start)
+	}
+	if to != "" {
+		if end, err = time.Parse(time.RFC3339, to); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid to %q: %v", to, err)
+		}
+		query = query.Where("cooked_at < ?", end)
+	}
+	if from != "" && to != "" && !start.Before(end) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+	return query, nil
+}
+
+// feedbackToProto converts a feedback entry to protobuf
+func feedbackToProto(f models.Feedback) *pb.Feedback {
+	return &pb.Feedback{
+		Id:              uint64(f.ID),
+		UserId:          f.UserID,
+		DishId:          f.DishID,
+		Rating:          f.Rating,
+		Skipped:         f.Skipped,
+		SubstitutedWith: f.SubstitutedWith,
+		Comment:         f.Comment,
+		CookedAt:        f.CookedAt.Format(time.RFC3339),
+	}
+}
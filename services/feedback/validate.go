@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+)
+
+// clockSkew tolerates client clocks running slightly ahead of ours when
+// checking that cooked_at is not in the future
+const clockSkew = 5 * time.Minute
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validEntry is a batch entry that passed validation, ready to be stored
+type validEntry struct {
+	index    int
+	feedback models.Feedback
+}
+
+// validateBatch checks every entry of a batch and returns one result per
+// entry, listing the reasons for rejection, along with the entries that
+// passed. Users and recipes are looked up once for the whole batch. Ids
+// are lowercased, the form Postgres returns uuids in.
+func (s *server) validateBatch(ctx context.Context, entries []*pb.Feedback) ([]*pb.FeedbackEntryResult, []validEntry, error) {
+	var userIDs, recipeIDs []string
+	for _, f := range entries {
+		if uuidPattern.MatchString(f.UserId) {
+			userIDs = append(userIDs, strings.ToLower(f.UserId))
+		}
+		if uuidPattern.MatchString(f.DishId) {
+			recipeIDs = append(recipeIDs, strings.ToLower(f.DishId))
+		}
+	}
+
+	users, err := s.existing(ctx, &models.User{}, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	recipes, err := s.existing(ctx, &models.Recipe{}, recipeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	results := make([]*pb.FeedbackEntryResult, len(entries))
+	var valid []validEntry
+	// first maps a user, dish and cooked_at to the first valid entry for
+	// them; later ones would overwrite it in the same row
+	first := make(map[string]int)
+	for i, f := range entries {
+		var reasons []string
+		userID := strings.ToLower(f.UserId)
+		dishID := strings.ToLower(f.DishId)
+
+		switch {
+		case f.UserId == "":
+			reasons = append(reasons, "user_id is required")
+		case !uuidPattern.MatchString(f.UserId):
+			reasons = append(reasons, fmt.Sprintf("user_id %q is not a valid id", f.UserId))
+		case !users[userID]:
+			reasons = append(reasons, fmt.Sprintf("user %s does not exist", f.UserId))
+		}
+
+		switch {
+		case f.DishId == "":
+			reasons = append(reasons, "dish_id is required")
+		case !uuidPattern.MatchString(f.DishId):
+			reasons = append(reasons, fmt.Sprintf("dish_id %q is not a valid id", f.DishId))
+		case !recipes[dishID]:
+			reasons = append(reasons, fmt.Sprintf("recipe %s does not exist", f.DishId))
+		}
+
+		// Skipped meals were never eaten, so they may go unrated
+		unrated := f.Skipped && f.Rating == 0
+		if !unrated && (f.Rating < 1 || f.Rating > 5) {
+			reasons = append(reasons, fmt.Sprintf("rating %d must be between 1 and 5", f.Rating))
+		}
+
+		cookedAt := now
+		if f.CookedAt != "" {
+			cookedAt, err = time.Parse(time.RFC3339, f.CookedAt)
+			if err != nil {
+				reasons = append(reasons, fmt.Sprintf("cooked_at %q is not an RFC 3339 timestamp", f.CookedAt))
+			} else if cookedAt.After(now.Add(clockSkew)) {
+				reasons = append(reasons, fmt.Sprintf("cooked_at %s is in the future", f.CookedAt))
+			}
+		}
+
+		if len(reasons) == 0 {
+			key := userID + " " + dishID + " " + cookedAt.UTC().Format(time.RFC3339Nano)
+			if j, ok := first[key]; ok {
+				reasons = append(reasons, fmt.Sprintf("duplicates entry %d for the same user, dish and cooked_at", j))
+			} else {
+				first[key] = i
+			}
+		}
+
+		results[i] = &pb.FeedbackEntryResult{Index: int32(i), Reasons: reasons}
+		if len(reasons) > 0 {
+			continue
+		}
+
+		valid = append(valid, validEntry{
+			index: i,
+			feedback: models.Feedback{
+				UserID:          userID,
+				DishID:          dishID,
+				Rating:          f.Rating,
+				Skipped:         f.Skipped,
+				SubstitutedWith: f.SubstitutedWith,
+				Comment:         f.Comment,
+				CookedAt:        cookedAt,
+			},
+		})
+	}
+
+	return results, valid, nil
+}
+
+// existing returns which of ids are present in the table behind model
+func (s *server) existing(ctx context.Context, model interface{}, ids []string) (map[string]bool, error) {
+	found := make(map[string]bool, len(ids))
+	if len(ids) == 0 {
+		return found, nil
+	}
+
+	var present []string
+	result := s.db.WithContext(ctx).Model(model).Where("id IN ?", ids).Pluck("id", &present)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	for _, id := range present {
+		found[id] = true
+	}
+	return found, nil
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"spiceroute/pkg/config"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys carrying the authenticated caller to downstream services
+const (
+	userIDMetadataKey = "x-user-id"
+	adminMetadataKey  = "x-user-admin"
+)
+
+// principal is the authenticated caller of a request
+type principal struct {
+	Subject string
+	Admin   bool
+}
+
+type principalKey struct{}
+
+// principalFrom returns the caller stored in ctx by the auth middleware
+func principalFrom(ctx context.Context) (principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(principal)
+	return p, ok
+}
+
+// claims are the JWT claims the gateway reads. Roles may come as a list or,
+// from simpler issuers, as a single role.
+type claims struct {
+	Roles []string `json:"roles"`
+	Role  string   `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// authenticator verifies bearer tokens signed with HS256 or RS256
+type authenticator struct {
+	cfg    config.Auth
+	secret []byte
+	keys   map[string]*rsa.PublicKey
+}
+
+func newAuthenticator(cfg config.Auth) (*authenticator, error) {
+	a := &authenticator{cfg: cfg, secret: []byte(cfg.JWTSecret)}
+	if cfg.JWKSFile != "" {
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		a.keys = keys
+	}
+	return a, nil
+}
+
+// middleware rejects requests without a valid bearer token and stores the
+// caller in the request context. The health check stays open for probes.
+func (a *authenticator) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.cfg.Disabled || r.URL.Path == "/health" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, codes.Unauthenticated, "missing bearer token")
+			return
+		}
+
+		p, err := a.verify(token)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeError(w, codes.Unauthenticated, "invalid token")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, p)))
+	})
+}
+
+// verify checks a token's signature and standard claims and returns the
+// caller it names
+func (a *authenticator) verify(token string) (principal, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"HS256", "RS256"}),
+		jwt.WithExpirationRequired(),
+	}
+	if a.cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.cfg.Issuer))
+	}
+	if a.cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(a.cfg.Audience))
+	}
+
+	var c claims
+	if _, err := jwt.ParseWithClaims(token, &c, a.key, opts...); err != nil {
+		return principal{}, err
+	}
+	if c.Subject == "" {
+		return principal{}, fmt.Errorf("token has no subject")
+	}
+
+	roles := c.Roles
+	if c.Role != "" {
+		roles = append(roles, c.Role)
+	}
+	return principal{Subject: c.Subject, Admin: slices.Contains(roles, a.cfg.AdminRole)}, nil
+}
+
+// key picks the verification key for a token's algorithm
+func (a *authenticator) key(t *jwt.Token) (interface{}, error) {
+	switch t.Method.Alg() {
+	case "HS256":
+		if len(a.secret) == 0 {
+			return nil, fmt.Errorf("HS256 tokens are not accepted")
+		}
+		return a.secret, nil
+	case "RS256":
+		kid, _ := t.Header["kid"].(string)
+		if key, ok := a.keys[kid]; ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+}
+
+// loadJWKS reads the RSA public keys of a JWKS file, keyed by key id
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %w", path, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil {
+			return nil, fmt.Errorf("JWKS key %q has a malformed modulus or exponent", k.Kid)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS file %s has no RSA keys", path)
+	}
+	return keys, nil
+}
+
+// canActFor reports whether the caller may access userID's data: their own,
+// or anyone's with the admin role. With auth disabled every call is allowed.
+func canActFor(r *http.Request, userID string) bool {
+	p, ok := principalFrom(r.Context())
+	if !ok {
+		return true
+	}
+	return p.Admin || p.Subject == userID
+}
+
+// ownUser guards routes with a {user_id} path parameter so callers can only
+// reach their own data unless they are admins
+func ownUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !canActFor(r, chi.URLParam(r, "user_id")) {
+			writeError(w, codes.PermissionDenied, "forbidden")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// adminOnly guards routes that change data shared by every user, such as
+// the recipe catalog. With auth disabled every call is allowed.
+func adminOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p, ok := principalFrom(r.Context()); ok && !p.Admin {
+			writeError(w, codes.PermissionDenied, "forbidden")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// forwardPrincipal is a client interceptor that passes the caller on to
+// downstream services as gRPC metadata
+func forwardPrincipal(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if p, ok := principalFrom(ctx); ok {
+		admin := "false"
+		if p.Admin {
+			admin = "true"
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, userIDMetadataKey, p.Subject, adminMetadataKey, admin)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpStatuses maps gRPC codes onto HTTP statuses, following the table
+// grpc-gateway uses. Codes not listed become 500.
+var httpStatuses = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499, // client closed request
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+// errorBody is the JSON body of every error response:
+//
+//	{"error": {"code": 404, "status": "NOT_FOUND", "message": "plan ... not found"}}
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// writeError writes an error response for a gRPC code
+func writeError(w http.ResponseWriter, code codes.Code, message string) {
+	httpStatus, ok := httpStatuses[code]
+	if !ok {
+		httpStatus = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(errorBody{Error: errorDetail{
+		Code:    httpStatus,
+		Status:  statusName(code),
+		Message: message,
+	}})
+}
+
+// writeGRPCError writes the error returned by a downstream call. Internal
+// failures are logged and reported without their details.
+func writeGRPCError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	switch s.Code() {
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		log.Printf("Downstream call failed: %v", err)
+		writeError(w, s.Code(), "internal error")
+	default:
+		writeError(w, s.Code(), s.Message())
+	}
+}
+
+// statusName turns a code's name into the upper snake case used by Google
+// APIs, e.g. NotFound becomes NOT_FOUND
+func statusName(code codes.Code) string {
+	var b strings.Builder
+	for i, r := range code.String() {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
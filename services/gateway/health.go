@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthTimeout bounds how long /health waits on downstream services
+const healthTimeout = 2 * time.Second
+
+// checkDownstream asks every downstream service for its gRPC health in
+// parallel. It returns each service's status and whether all are serving.
+func checkDownstream(ctx context.Context, conns map[string]*grpc.ClientConn) (map[string]string, bool) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	statuses := make(map[string]string, len(conns))
+	healthy := true
+
+	for name, conn := range conns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			state := checkService(ctx, conn)
+
+			mu.Lock()
+			defer mu.Unlock()
+			statuses[name] = state
+			if state != healthpb.HealthCheckResponse_SERVING.String() {
+				healthy = false
+			}
+		}()
+	}
+	wg.Wait()
+
+	return statuses, healthy
+}
+
+// checkService returns the health status one service reports
+func checkService(ctx context.Context, conn *grpc.ClientConn) string {
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		// Every service registers health checks, so a server without them
+		// is not the service we expect, e.g. another process on its port
+		if status.Code(err) == codes.Unimplemented {
+			return healthpb.HealthCheckResponse_UNKNOWN.String()
+		}
+		return "UNREACHABLE"
+	}
+	return resp.Status.String()
+}
@@ -3,41 +3,86 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
 
+	"spiceroute/pkg/config"
 	pb "spiceroute/proto"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func main() {
+	cfg, err := config.Load("gateway")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
 	// Initialize gRPC connections
-	profileConn, _ := grpc.NewClient("profile:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
-	plannerConn, _ := grpc.NewClient("planner:50052", grpc.WithTransportCredentials(insecure.NewCredentials()))
-	recipesConn, _ := grpc.NewClient("recipes:50053", grpc.WithTransportCredentials(insecure.NewCredentials()))
-	feedbackConn, _ := grpc.NewClient("feedback:50056", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conns := make(map[string]*grpc.ClientConn)
+	for _, name := range []string{"profile", "planner", "recipes", "feedback", "plans", "shopping", "vector"} {
+		conn, err := grpc.NewClient(cfg.Address(name),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(forwardPrincipal),
+		)
+		if err != nil {
+			log.Fatalf("Failed to create %s client: %v", name, err)
+		}
+		defer conn.Close()
+		conns[name] = conn
+	}
 
 	// Initialize service clients
-	profile := pb.NewProfileServiceClient(profileConn)
-	planner := pb.NewPlannerServiceClient(plannerConn)
-	recipes := pb.NewRecipeServiceClient(recipesConn)
-	feedback := pb.NewFeedbackServiceClient(feedbackConn)
+	profile := pb.NewProfileServiceClient(conns["profile"])
+	planner := pb.NewPlannerServiceClient(conns["planner"])
+	recipes := pb.NewRecipeServiceClient(conns["recipes"])
+	feedback := pb.NewFeedbackServiceClient(conns["feedback"])
+	plans := pb.NewPlanServiceClient(conns["plans"])
+	shopping := pb.NewShoppingServiceClient(conns["shopping"])
+	pantry := pb.NewPantryServiceClient(conns["shopping"])
+	vectors := pb.NewVectorServiceClient(conns["vector"])
+
+	auth, err := newAuthenticator(cfg.Auth)
+	if err != nil {
+		log.Fatal("Failed to set up authentication:", err)
+	}
 
 	r := chi.NewRouter()
 
 	// Middleware
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
+	r.Use(auth.middleware)
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, codes.NotFound, "no route for "+r.URL.Path)
+	})
 
-	// Health check
+	// Health check, healthy only when every downstream service is serving
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
+		ctx, cancel := context.WithTimeout(r.Context(), healthTimeout)
+		defer cancel()
+
+		services, healthy := checkDownstream(ctx, conns)
+		code, state := http.StatusOK, "healthy"
+		if !healthy {
+			code, state = http.StatusServiceUnavailable, "degraded"
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":   state,
+			"services": services,
+		})
 	})
 
 	// User Preferences & Profile APIs
@@ -46,10 +91,14 @@ func main() {
 			body, _ := io.ReadAll(r.Body)
 			var pref pb.Preference
 			json.Unmarshal(body, &pref)
+			if !canActFor(r, pref.UserId) {
+				writeError(w, codes.PermissionDenied, "forbidden")
+				return
+			}
 
-			result, err := profile.UpsertPreference(context.Background(), &pref)
+			result, err := profile.UpsertPreference(r.Context(), &pref)
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				writeGRPCError(w, err)
 				return
 			}
 
@@ -57,13 +106,13 @@ func main() {
 			json.NewEncoder(w).Encode(result)
 		})
 
-		r.Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+		r.With(ownUser).Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
 			userID := chi.URLParam(r, "user_id")
 			pref := &pb.Preference{UserId: userID}
 
-			result, err := profile.GetPreference(context.Background(), pref)
+			result, err := profile.GetPreference(r.Context(), pref)
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				writeGRPCError(w, err)
 				return
 			}
 
@@ -71,16 +120,99 @@ func main() {
 			json.NewEncoder(w).Encode(result)
 		})
 
-		r.Put("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+		r.With(ownUser).Put("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
 			userID := chi.URLParam(r, "user_id")
 			body, _ := io.ReadAll(r.Body)
 			var pref pb.Preference
 			json.Unmarshal(body, &pref)
 			pref.UserId = userID
 
-			result, err := profile.UpsertPreference(context.Background(), &pref)
+			result, err := profile.UpsertPreference(r.Context(), &pref)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Get("/{user_id}/mood", func(w http.ResponseWriter, r *http.Request) {
+			result, err := profile.GetMood(r.Context(), &pb.UserRef{UserId: chi.URLParam(r, "user_id")})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Put("/{user_id}/mood", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var mood pb.Mood
+			json.Unmarshal(body, &mood)
+			mood.UserId = chi.URLParam(r, "user_id")
+
+			result, err := profile.SetMood(r.Context(), &mood)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Delete("/{user_id}/mood", func(w http.ResponseWriter, r *http.Request) {
+			_, err := profile.ClearMood(r.Context(), &pb.Mood{
+				UserId:    chi.URLParam(r, "user_id"),
+				WeekStart: r.URL.Query().Get("week_start"),
+			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		r.With(ownUser).Get("/{user_id}/cuisines", func(w http.ResponseWriter, r *http.Request) {
+			result, err := profile.GetEffectiveCuisines(r.Context(), &pb.UserRef{UserId: chi.URLParam(r, "user_id")})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		// PATCH writes only the fields present in the body
+		r.With(ownUser).Patch("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(body, &fields); err != nil {
+				writeError(w, codes.InvalidArgument, "body must be a JSON object")
+				return
+			}
+			var pref pb.Preference
+			json.Unmarshal(body, &pref)
+			pref.UserId = chi.URLParam(r, "user_id")
+			pref.UpdateMask = &fieldmaskpb.FieldMask{}
+			for field := range fields {
+				if field != "user_id" && field != "update_mask" {
+					pref.UpdateMask.Paths = append(pref.UpdateMask.Paths, field)
+				}
+			}
+			if len(pref.UpdateMask.Paths) == 0 {
+				writeError(w, codes.InvalidArgument, "no fields to update")
+				return
+			}
+
+			result, err := profile.UpsertPreference(r.Context(), &pref)
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				writeGRPCError(w, err)
 				return
 			}
 
@@ -89,6 +221,66 @@ func main() {
 		})
 	})
 
+	// User Account APIs
+	r.Route("/users", func(r chi.Router) {
+		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var user pb.User
+			json.Unmarshal(body, &user)
+			// Signed-in callers register the account their token names
+			if p, ok := principalFrom(r.Context()); ok && user.Id == "" {
+				user.Id = p.Subject
+			}
+			if !canActFor(r, user.Id) {
+				writeError(w, codes.PermissionDenied, "forbidden")
+				return
+			}
+
+			result, err := profile.CreateUser(r.Context(), &user)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+			result, err := profile.GetUser(r.Context(), &pb.UserRef{UserId: chi.URLParam(r, "user_id")})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Delete("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+			_, err := profile.DeleteUser(r.Context(), &pb.UserRef{UserId: chi.URLParam(r, "user_id")})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		r.With(ownUser).Get("/{user_id}/export", func(w http.ResponseWriter, r *http.Request) {
+			result, err := profile.ExportUserData(r.Context(), &pb.UserRef{UserId: chi.URLParam(r, "user_id")})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="spiceroute-%s.json"`, result.UserId))
+			w.Write(result.Document)
+		})
+	})
+
 	// Recipe Management APIs
 	r.Route("/recipes", func(r chi.Router) {
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
@@ -99,14 +291,40 @@ func main() {
 				spicy = true
 			}
 
+			pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+			maxPrep, _ := strconv.Atoi(r.URL.Query().Get("max_prep_minutes"))
+			minCalories, _ := strconv.Atoi(r.URL.Query().Get("min_calories"))
+			maxCalories, _ := strconv.Atoi(r.URL.Query().Get("max_calories"))
+			maxCost, _ := strconv.ParseFloat(r.URL.Query().Get("max_cost"), 64)
+
 			query := &pb.RecipeQuery{
-				Cuisines: cuisines,
-				Spicy:    spicy,
+				Cuisines:                cuisines,
+				Spicy:                   spicy,
+				PageSize:                int32(pageSize),
+				PageToken:               r.URL.Query().Get("page_token"),
+				OrderBy:                 r.URL.Query().Get("order_by"),
+				Descending:              r.URL.Query().Get("desc") == "true",
+				MaxPrepMinutes:          int32(maxPrep),
+				MinCalories:             int32(minCalories),
+				MaxCalories:             int32(maxCalories),
+				MaxCost:                 maxCost,
+				Tags:                    r.URL.Query()["tag"],
+				ExcludeIngredients:      r.URL.Query()["exclude_ingredient"],
+				ExcludeAllergensForUser: r.URL.Query().Get("safe_for_user"),
+				CuisinesForUser:         r.URL.Query().Get("for_user"),
+			}
+			// Filtering by a user's allergies or tastes reveals them, so it is
+			// theirs to ask
+			for _, userID := range []string{query.ExcludeAllergensForUser, query.CuisinesForUser} {
+				if userID != "" && !canActFor(r, userID) {
+					writeError(w, codes.PermissionDenied, "forbidden")
+					return
+				}
 			}
 
-			result, err := recipes.ListRecipes(context.Background(), query)
+			result, err := recipes.ListRecipes(r.Context(), query)
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				writeGRPCError(w, err)
 				return
 			}
 
@@ -116,20 +334,27 @@ func main() {
 
 		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
 			recipeID := chi.URLParam(r, "id")
-			// Note: This would need a GetRecipe method in the proto
-			// For now, we'll return a placeholder
+
+			result, err := recipes.GetRecipe(r.Context(), &pb.RecipeID{Id: recipeID})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{"id": recipeID, "message": "Recipe details endpoint"})
+			json.NewEncoder(w).Encode(result)
 		})
 
-		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
+		r.With(adminOnly).Put("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			recipeID := chi.URLParam(r, "id")
 			body, _ := io.ReadAll(r.Body)
 			var recipe pb.Recipe
 			json.Unmarshal(body, &recipe)
+			recipe.Id = recipeID
 
-			result, err := recipes.CreateRecipe(context.Background(), &recipe)
+			result, err := recipes.UpdateRecipe(r.Context(), &recipe)
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				writeGRPCError(w, err)
 				return
 			}
 
@@ -137,27 +362,122 @@ func main() {
 			json.NewEncoder(w).Encode(result)
 		})
 
-		r.Get("/search", func(w http.ResponseWriter, r *http.Request) {
-			query := r.URL.Query().Get("q")
-			cuisines := r.URL.Query()["cuisine"]
+		r.With(adminOnly).Delete("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			recipeID := chi.URLParam(r, "id")
+
+			_, err := recipes.DeleteRecipe(r.Context(), &pb.RecipeID{Id: recipeID})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		r.With(adminOnly).Post("/{id}/restore", func(w http.ResponseWriter, r *http.Request) {
+			recipeID := chi.URLParam(r, "id")
+
+			result, err := recipes.RestoreRecipe(r.Context(), &pb.RecipeID{Id: recipeID})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
 
-			// This would integrate with the Vector service for semantic search
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"query":    query,
-				"cuisines": cuisines,
-				"message":  "Semantic search endpoint",
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.Get("/{id}/export", func(w http.ResponseWriter, r *http.Request) {
+			result, err := recipes.ExportRecipes(r.Context(), &pb.RecipeExportRequest{
+				RecipeIds: []string{chi.URLParam(r, "id")},
+				Format:    r.URL.Query().Get("format"),
 			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			writeExport(w, result)
 		})
 
-		r.Get("/recommendations", func(w http.ResponseWriter, r *http.Request) {
-			userID := r.URL.Query().Get("user_id")
-			// This would integrate with the Vector service for recommendations
+		r.With(adminOnly).Post("/", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var recipe pb.Recipe
+			json.Unmarshal(body, &recipe)
+
+			result, err := recipes.CreateRecipe(r.Context(), &recipe)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"user_id": userID,
-				"message": "Personalized recommendations endpoint",
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.Get("/search", func(w http.ResponseWriter, r *http.Request) {
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+			// mode=semantic matches by meaning instead of by words
+			if r.URL.Query().Get("mode") == "semantic" {
+				minSimilarity, _ := strconv.ParseFloat(r.URL.Query().Get("min_similarity"), 64)
+				result, err := vectors.SearchSimilar(r.Context(), &pb.SemanticSearchRequest{
+					Query:         r.URL.Query().Get("q"),
+					Cuisines:      r.URL.Query()["cuisine"],
+					Limit:         int32(limit),
+					MinSimilarity: minSimilarity,
+				})
+				if err != nil {
+					writeGRPCError(w, err)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(result)
+				return
+			}
+
+			result, err := recipes.SearchRecipes(r.Context(), &pb.RecipeSearchRequest{
+				Query:    r.URL.Query().Get("q"),
+				Cuisines: r.URL.Query()["cuisine"],
+				Limit:    int32(limit),
+				Offset:   int32(offset),
 			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.Get("/recommendations", func(w http.ResponseWriter, r *http.Request) {
+			// Recommend for the caller unless another user or recipes to
+			// match are named
+			req := &pb.RecommendationRequest{
+				UserId:    r.URL.Query().Get("user_id"),
+				RecipeIds: r.URL.Query()["like"],
+			}
+			if p, ok := principalFrom(r.Context()); ok && req.UserId == "" && len(req.RecipeIds) == 0 {
+				req.UserId = p.Subject
+			}
+			if req.UserId != "" && !canActFor(r, req.UserId) {
+				writeError(w, codes.PermissionDenied, "forbidden")
+				return
+			}
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			req.Limit = int32(limit)
+
+			result, err := vectors.Recommend(r.Context(), req)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
 		})
 	})
 
@@ -167,71 +487,280 @@ func main() {
 			body, _ := io.ReadAll(r.Body)
 			var req pb.PlanRequest
 			json.Unmarshal(body, &req)
+			if !canActFor(r, req.UserId) {
+				writeError(w, codes.PermissionDenied, "forbidden")
+				return
+			}
+
+			// Steer the planner towards pantry items that are about to expire
+			if len(req.PrioritizeIngredients) == 0 && req.UserId != "" {
+				expiring, err := pantry.ListPantryItems(r.Context(), &pb.PantryQuery{
+					UserId:       req.UserId,
+					ExpiringOnly: true,
+				})
+				if err == nil {
+					for _, item := range expiring.Items {
+						req.PrioritizeIngredients = append(req.PrioritizeIngredients, item.CanonicalId)
+					}
+				}
+			}
+
+			// Favour the cuisines the user wants this week
+			if len(req.PreferredCuisines) == 0 && req.UserId != "" {
+				effective, err := profile.GetEffectiveCuisines(r.Context(), &pb.UserRef{UserId: req.UserId})
+				if err == nil {
+					req.PreferredCuisines = effective.Cuisines
+				}
+			}
+
+			// Saved plans reference recipes, so only catalog dishes can be planned
+			if err := checkCatalogDishes(r.Context(), recipes, req.Dishes); err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			result, err := planner.GeneratePlan(r.Context(), &req)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
 
-			result, err := planner.GeneratePlan(context.Background(), &req)
+			// Keep the plan so the user can revisit it later
+			saved, err := plans.SavePlan(r.Context(), &pb.MealPlan{
+				UserId:    req.UserId,
+				WeekStart: r.URL.Query().Get("week_start"),
+				Request:   &req,
+				Plan:      result,
+			})
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				writeGRPCError(w, err)
 				return
 			}
 
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(result)
+			json.NewEncoder(w).Encode(saved)
 		})
 
-		r.Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+		r.With(ownUser).Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
 			userID := chi.URLParam(r, "user_id")
-			// This would need a GetPlans method in the proto
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"user_id": userID,
-				"message": "Get user meal plans endpoint",
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+			result, err := plans.ListPlans(r.Context(), &pb.PlanListRequest{
+				UserId: userID,
+				Limit:  int32(limit),
 			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
 		})
 
-		r.Get("/{user_id}/{plan_id}", func(w http.ResponseWriter, r *http.Request) {
-			userID := chi.URLParam(r, "user_id")
-			planID := chi.URLParam(r, "plan_id")
+		r.With(ownUser).Get("/{user_id}/{plan_id}", func(w http.ResponseWriter, r *http.Request) {
+			lookup := &pb.PlanLookup{
+				UserId: chi.URLParam(r, "user_id"),
+				PlanId: chi.URLParam(r, "plan_id"),
+			}
+
+			result, err := plans.GetPlan(r.Context(), lookup)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"user_id": userID,
-				"plan_id": planID,
-				"message": "Get specific meal plan endpoint",
-			})
+			json.NewEncoder(w).Encode(result)
 		})
 
-		r.Post("/{user_id}/{plan_id}/regenerate", func(w http.ResponseWriter, r *http.Request) {
-			userID := chi.URLParam(r, "user_id")
-			planID := chi.URLParam(r, "plan_id")
+		r.With(ownUser).Delete("/{user_id}/{plan_id}", func(w http.ResponseWriter, r *http.Request) {
+			lookup := &pb.PlanLookup{
+				UserId: chi.URLParam(r, "user_id"),
+				PlanId: chi.URLParam(r, "plan_id"),
+			}
+
+			_, err := plans.DeletePlan(r.Context(), lookup)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		r.With(ownUser).Post("/{user_id}/{plan_id}/regenerate", func(w http.ResponseWriter, r *http.Request) {
+			lookup := &pb.PlanLookup{
+				UserId: chi.URLParam(r, "user_id"),
+				PlanId: chi.URLParam(r, "plan_id"),
+			}
+
+			result, err := plans.RegeneratePlan(r.Context(), lookup)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"user_id": userID,
-				"plan_id": planID,
-				"message": "Regenerate meal plan endpoint",
+			json.NewEncoder(w).Encode(result)
+		})
+
+		// The plan's recipes as one cookbook document
+		r.With(ownUser).Get("/{user_id}/{plan_id}/cookbook", func(w http.ResponseWriter, r *http.Request) {
+			result, err := recipes.ExportRecipes(r.Context(), &pb.RecipeExportRequest{
+				UserId: chi.URLParam(r, "user_id"),
+				PlanId: chi.URLParam(r, "plan_id"),
+				Format: r.URL.Query().Get("format"),
 			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			writeExport(w, result)
 		})
 	})
 
 	// Shopping & Ordering APIs
 	r.Route("/shopping", func(r chi.Router) {
-		r.Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+		r.With(ownUser).Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
 			userID := chi.URLParam(r, "user_id")
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+			result, err := shopping.ListShoppingLists(r.Context(), &pb.ShoppingListQuery{
+				UserId: userID,
+				Limit:  int32(limit),
+			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"user_id": userID,
-				"message": "Get shopping lists endpoint",
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Post("/{user_id}/generate", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var req pb.GenerateShoppingListRequest
+			json.Unmarshal(body, &req)
+			req.UserId = chi.URLParam(r, "user_id")
+
+			result, err := shopping.GenerateShoppingList(r.Context(), &req)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Get("/{user_id}/{list_id}", func(w http.ResponseWriter, r *http.Request) {
+			lookup := &pb.ShoppingListLookup{
+				UserId: chi.URLParam(r, "user_id"),
+				ListId: chi.URLParam(r, "list_id"),
+			}
+
+			result, err := shopping.GetShoppingList(r.Context(), lookup)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Post("/{user_id}/{list_id}/items", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var item pb.ShoppingItem
+			json.Unmarshal(body, &item)
+			item.Id = 0
+
+			result, err := shopping.UpsertShoppingItem(r.Context(), &pb.ShoppingItemUpdate{
+				UserId: chi.URLParam(r, "user_id"),
+				ListId: chi.URLParam(r, "list_id"),
+				Item:   &item,
 			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
 		})
 
-		r.Post("/{user_id}/generate", func(w http.ResponseWriter, r *http.Request) {
-			userID := chi.URLParam(r, "user_id")
+		r.With(ownUser).Put("/{user_id}/{list_id}/items/{item_id}", func(w http.ResponseWriter, r *http.Request) {
+			itemID, err := strconv.ParseUint(chi.URLParam(r, "item_id"), 10, 64)
+			if err != nil {
+				writeError(w, codes.InvalidArgument, "invalid item id")
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			var item pb.ShoppingItem
+			json.Unmarshal(body, &item)
+			item.Id = itemID
+
+			result, err := shopping.UpsertShoppingItem(r.Context(), &pb.ShoppingItemUpdate{
+				UserId: chi.URLParam(r, "user_id"),
+				ListId: chi.URLParam(r, "list_id"),
+				Item:   &item,
+			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"user_id": userID,
-				"message": "Generate shopping list endpoint",
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Put("/{user_id}/{list_id}/items/{item_id}/checked", func(w http.ResponseWriter, r *http.Request) {
+			itemID, err := strconv.ParseUint(chi.URLParam(r, "item_id"), 10, 64)
+			if err != nil {
+				writeError(w, codes.InvalidArgument, "invalid item id")
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			var check pb.ShoppingItemCheck
+			json.Unmarshal(body, &check)
+			check.UserId = chi.URLParam(r, "user_id")
+			check.ListId = chi.URLParam(r, "list_id")
+			check.ItemId = itemID
+
+			result, err := shopping.SetShoppingItemChecked(r.Context(), &check)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Delete("/{user_id}/{list_id}/items/{item_id}", func(w http.ResponseWriter, r *http.Request) {
+			itemID, err := strconv.ParseUint(chi.URLParam(r, "item_id"), 10, 64)
+			if err != nil {
+				writeError(w, codes.InvalidArgument, "invalid item id")
+				return
+			}
+
+			_, err = shopping.DeleteShoppingItem(r.Context(), &pb.ShoppingItemRef{
+				UserId: chi.URLParam(r, "user_id"),
+				ListId: chi.URLParam(r, "list_id"),
+				ItemId: itemID,
 			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
 		})
 
-		r.Post("/{user_id}/{list_id}/order", func(w http.ResponseWriter, r *http.Request) {
+		r.With(ownUser).Post("/{user_id}/{list_id}/order", func(w http.ResponseWriter, r *http.Request) {
 			userID := chi.URLParam(r, "user_id")
 			listID := chi.URLParam(r, "list_id")
 			// This would integrate with the Orderer service
@@ -243,7 +772,7 @@ func main() {
 			})
 		})
 
-		r.Get("/{user_id}/orders", func(w http.ResponseWriter, r *http.Request) {
+		r.With(ownUser).Get("/{user_id}/orders", func(w http.ResponseWriter, r *http.Request) {
 			userID := chi.URLParam(r, "user_id")
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(map[string]string{
@@ -253,45 +782,168 @@ func main() {
 		})
 	})
 
+	// Pantry APIs
+	r.Route("/pantry", func(r chi.Router) {
+		r.With(ownUser).Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+			days, _ := strconv.Atoi(r.URL.Query().Get("expiring_within_days"))
+
+			result, err := pantry.ListPantryItems(r.Context(), &pb.PantryQuery{
+				UserId:             chi.URLParam(r, "user_id"),
+				ExpiringWithinDays: int32(days),
+				ExpiringOnly:       r.URL.Query().Get("expiring_only") == "true",
+			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Post("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var item pb.PantryItem
+			json.Unmarshal(body, &item)
+			item.Id = 0
+			item.UserId = chi.URLParam(r, "user_id")
+
+			result, err := pantry.UpsertPantryItem(r.Context(), &item)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Put("/{user_id}/{item_id}", func(w http.ResponseWriter, r *http.Request) {
+			itemID, err := strconv.ParseUint(chi.URLParam(r, "item_id"), 10, 64)
+			if err != nil {
+				writeError(w, codes.InvalidArgument, "invalid item id")
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			var item pb.PantryItem
+			json.Unmarshal(body, &item)
+			item.Id = itemID
+			item.UserId = chi.URLParam(r, "user_id")
+
+			result, err := pantry.UpsertPantryItem(r.Context(), &item)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.With(ownUser).Delete("/{user_id}/{item_id}", func(w http.ResponseWriter, r *http.Request) {
+			itemID, err := strconv.ParseUint(chi.URLParam(r, "item_id"), 10, 64)
+			if err != nil {
+				writeError(w, codes.InvalidArgument, "invalid item id")
+				return
+			}
+
+			_, err = pantry.DeletePantryItem(r.Context(), &pb.PantryItemRef{
+				UserId: chi.URLParam(r, "user_id"),
+				ItemId: itemID,
+			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	})
+
 	// Feedback APIs
 	r.Route("/feedback", func(r chi.Router) {
 		r.Post("/", func(w http.ResponseWriter, r *http.Request) {
 			body, _ := io.ReadAll(r.Body)
 			var feedbackBatch pb.FeedbackBatch
 			json.Unmarshal(body, &feedbackBatch)
+			for _, entry := range feedbackBatch.Entries {
+				if !canActFor(r, entry.UserId) {
+					writeError(w, codes.PermissionDenied, "forbidden")
+					return
+				}
+			}
 
-			result, err := feedback.SubmitFeedback(context.Background(), &feedbackBatch)
+			result, err := feedback.SubmitFeedback(r.Context(), &feedbackBatch)
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				writeGRPCError(w, err)
 				return
 			}
 
 			w.Header().Set("Content-Type", "application/json")
+			// Report a batch that stored nothing as unprocessable, with the
+			// per-entry reasons in the body
+			if result.Accepted == 0 && result.Rejected > 0 {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+			}
 			json.NewEncoder(w).Encode(result)
 		})
 
-		r.Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
-			userID := chi.URLParam(r, "user_id")
+		r.With(ownUser).Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+			result, err := feedback.ListFeedbackByUser(r.Context(), feedbackQuery(r, &pb.FeedbackQuery{
+				UserId: chi.URLParam(r, "user_id"),
+			}))
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"user_id": userID,
-				"message": "Get user feedback history endpoint",
-			})
+			json.NewEncoder(w).Encode(result)
 		})
 
 		r.Get("/recipes/{recipe_id}", func(w http.ResponseWriter, r *http.Request) {
-			recipeID := chi.URLParam(r, "recipe_id")
+			result, err := feedback.ListFeedbackByRecipe(r.Context(), feedbackQuery(r, &pb.FeedbackQuery{
+				RecipeId: chi.URLParam(r, "recipe_id"),
+			}))
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			// Other users' entries are shown without who wrote them or
+			// their comments, unless the caller is an admin
+			if p, ok := principalFrom(r.Context()); ok && !p.Admin {
+				for _, entry := range result.Entries {
+					if entry.UserId != p.Subject {
+						entry.UserId = ""
+						entry.Comment = ""
+					}
+				}
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"recipe_id": recipeID,
-				"message":   "Get recipe ratings endpoint",
+			json.NewEncoder(w).Encode(result)
+		})
+
+		r.Get("/recipes/{recipe_id}/summary", func(w http.ResponseWriter, r *http.Request) {
+			result, err := feedback.GetRecipeRatingSummary(r.Context(), &pb.RatingSummaryRequest{
+				RecipeId: chi.URLParam(r, "recipe_id"),
+				From:     r.URL.Query().Get("from"),
+				To:       r.URL.Query().Get("to"),
 			})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(result)
 		})
 	})
 
 	// Analytics APIs
 	r.Route("/analytics", func(r chi.Router) {
-		r.Get("/{user_id}/nutrition", func(w http.ResponseWriter, r *http.Request) {
+		r.With(ownUser).Get("/{user_id}/nutrition", func(w http.ResponseWriter, r *http.Request) {
 			userID := chi.URLParam(r, "user_id")
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(map[string]string{
@@ -300,7 +952,7 @@ func main() {
 			})
 		})
 
-		r.Get("/{user_id}/spending", func(w http.ResponseWriter, r *http.Request) {
+		r.With(ownUser).Get("/{user_id}/spending", func(w http.ResponseWriter, r *http.Request) {
 			userID := chi.URLParam(r, "user_id")
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(map[string]string{
@@ -309,7 +961,7 @@ func main() {
 			})
 		})
 
-		r.Get("/{user_id}/cooking-time", func(w http.ResponseWriter, r *http.Request) {
+		r.With(ownUser).Get("/{user_id}/cooking-time", func(w http.ResponseWriter, r *http.Request) {
 			userID := chi.URLParam(r, "user_id")
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(map[string]string{
@@ -322,24 +974,108 @@ func main() {
 	// Health & Dietary APIs
 	r.Route("/nutrition", func(r chi.Router) {
 		r.Get("/calculator", func(w http.ResponseWriter, r *http.Request) {
+			recipeID := r.URL.Query().Get("recipe_id")
+			servings, err := strconv.Atoi(r.URL.Query().Get("servings"))
+			if err != nil || servings <= 0 {
+				servings = 1
+			}
+
+			recipe, err := recipes.GetRecipe(r.Context(), &pb.RecipeID{Id: recipeID})
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
+			// Scale per-serving facts up to the requested number of servings
+			totals := &pb.NutritionFacts{Servings: int32(servings)}
+			if facts := recipe.NutritionFacts; facts != nil {
+				perServing := 1.0
+				if !facts.PerServing && facts.Servings > 1 {
+					perServing = 1 / float64(facts.Servings)
+				}
+				scale := perServing * float64(servings)
+				totals.ProteinG = facts.ProteinG * scale
+				totals.CarbsG = facts.CarbsG * scale
+				totals.FatG = facts.FatG * scale
+				totals.FiberG = facts.FiberG * scale
+				totals.SodiumMg = facts.SodiumMg * scale
+				totals.SugarG = facts.SugarG * scale
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"message": "Nutrition calculator endpoint",
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"recipe_id": recipe.Id,
+				"servings":  servings,
+				"calories":  int(recipe.Calories) * servings,
+				"nutrition": totals,
 			})
 		})
 	})
 
 	r.Route("/allergies", func(r chi.Router) {
 		r.Get("/check", func(w http.ResponseWriter, r *http.Request) {
-			recipeID := r.URL.Query().Get("recipe_id")
+			req := &pb.AllergenCheckRequest{
+				UserId:    r.URL.Query().Get("user_id"),
+				RecipeIds: r.URL.Query()["recipe_id"],
+			}
+			if !canActFor(r, req.UserId) {
+				writeError(w, codes.PermissionDenied, "forbidden")
+				return
+			}
+
+			result, err := recipes.CheckAllergens(r.Context(), req)
+			if err != nil {
+				writeGRPCError(w, err)
+				return
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(map[string]string{
-				"recipe_id": recipeID,
-				"message":   "Check recipe for allergens endpoint",
-			})
+			json.NewEncoder(w).Encode(result)
 		})
 	})
 
-	log.Printf("Gateway service starting on port 8080")
-	log.Fatal(http.ListenAndServe(":8080", r))
+	log.Printf("Gateway service starting on %s", cfg.ListenAddr())
+	log.Fatal(http.ListenAndServe(cfg.ListenAddr(), r))
+}
+
+// writeExport sends an exported document with its content type and a file
+// name, inline so browsers can show and print HTML cards directly
+func writeExport(w http.ResponseWriter, export *pb.RecipeExport) {
+	w.Header().Set("Content-Type", export.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, export.Filename))
+	w.Write(export.Document)
+}
+
+// checkCatalogDishes makes sure every dish of a plan request is a stored
+// recipe, reporting the first one that is not
+func checkCatalogDishes(ctx context.Context, recipes pb.RecipeServiceClient, dishes []*pb.Dish) error {
+	checked := make(map[string]bool, len(dishes))
+	for _, dish := range dishes {
+		if checked[dish.Id] {
+			continue
+		}
+		checked[dish.Id] = true
+
+		_, err := recipes.GetRecipe(ctx, &pb.RecipeID{Id: dish.Id})
+		switch status.Code(err) {
+		case codes.OK:
+		case codes.NotFound, codes.InvalidArgument:
+			return status.Errorf(codes.InvalidArgument, "dish %q is not a recipe in the catalog", dish.Id)
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// feedbackQuery fills the date range and paging parameters of a feedback
+// listing from the request's query string
+func feedbackQuery(r *http.Request, q *pb.FeedbackQuery) *pb.FeedbackQuery {
+	params := r.URL.Query()
+	pageSize, _ := strconv.Atoi(params.Get("page_size"))
+	q.From = params.Get("from")
+	q.To = params.Get("to")
+	q.PageSize = int32(pageSize)
+	q.PageToken = params.Get("page_token")
+	return q
 }
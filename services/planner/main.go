@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"spiceroute/pkg/config"
+	"spiceroute/pkg/grpcserver"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type server struct {
+	pb.UnimplementedPlannerServiceServer
+}
+
+func (s *server) GeneratePlan(ctx context.Context, req *pb.PlanRequest) (*pb.PlanResponse, error) {
+	if len(req.Dishes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one dish is required")
+	}
+	if req.Days < 0 || req.DailyCalories < 0 || req.BudgetWeek < 0 {
+		return nil, status.Error(codes.InvalidArgument, "days, daily_calories and budget_week must not be negative")
+	}
+
+	plan, err := Solve(req)
+	if err != nil {
+		var over errOverBudget
+		if errors.As(err, &over) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return plan, nil
+}
+
+func main() {
+	cfg, err := config.Load("planner")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	// Start gRPC server
+	grpcServer := grpcserver.NewServer()
+	pb.RegisterPlannerServiceServer(grpcServer, &server{})
+
+	log.Printf("Planner service starting on %s", cfg.ListenAddr())
+	if err := grpcserver.Serve(grpcServer, cfg, nil); err != nil {
+		log.Fatal("Failed to serve:", err)
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"spiceroute/pkg/ingredients"
+	pb "spiceroute/proto"
+)
+
+const (
+	defaultDays          = 7
+	defaultDailyCalories = 2000
+	// calorieTolerance is how far a day may land from its calorie target
+	calorieTolerance = 0.1
+	// maxBatchDays caps how many consecutive days one cooked batch feeds,
+	// trading fewer cook days against eating the same dish all week
+	maxBatchDays = 3
+	// dishesPerDay is how many dishes a day's calories are split across
+	dishesPerDay = 2
+	// maxDishesPerDay caps how many different dishes make up one day
+	maxDishesPerDay = 3
+)
+
+// errOverBudget is returned when even the cheapest schedule found exceeds
+// the weekly budget
+type errOverBudget struct {
+	cost, budget float64
+}
+
+func (e errOverBudget) Error() string {
+	return fmt.Sprintf("cheapest plan found costs %.2f, over the budget of %.2f", e.cost, e.budget)
+}
+
+// batch is a cooked dish with servings left over for later days
+type batch struct {
+	dish      *pb.Dish
+	perDay    int32
+	remaining int32
+	expires   int
+}
+
+// solver builds a plan greedily, one day at a time. Each day eats leftovers
+// first, then cooks new batches sized to cover that day and, within the
+// dish's shelf life, the following days, so fewer days need cooking.
+type solver struct {
+	dishes   []*pb.Dish
+	days     int
+	target   float64
+	budget   float64
+	spent    float64
+	batches  []*batch
+	cooked   map[string]int
+	lastEat  map[string]int
+	cookDays []int
+	// priority counts, per dish, the ingredients that should be used up first
+	priority map[string]int
+	// preferred holds the lowercased cuisines the user wants this week
+	preferred map[string]bool
+}
+
+// Solve produces a schedule for req that lands each day near the calorie
+// target, stays within the weekly budget and minimizes cook days by
+// planning leftovers within each dish's shelf_life_days. It is
+// deterministic: the same request always yields the same plan.
+func Solve(req *pb.PlanRequest) (*pb.PlanResponse, error) {
+	s := &solver{
+		days:      int(req.Days),
+		target:    req.DailyCalories,
+		cooked:    make(map[string]int),
+		lastEat:   make(map[string]int),
+		priority:  make(map[string]int),
+		preferred: make(map[string]bool),
+	}
+	if s.days <= 0 {
+		s.days = defaultDays
+	}
+	if s.target <= 0 {
+		s.target = defaultDailyCalories
+	}
+	// The budget is weekly; scale it to the planned number of days
+	if req.BudgetWeek > 0 {
+		s.budget = req.BudgetWeek * float64(s.days) / 7
+	}
+
+	for _, dish := range req.Dishes {
+		if dish.Calories > 0 {
+			s.dishes = append(s.dishes, dish)
+		}
+	}
+	if len(s.dishes) == 0 {
+		return nil, fmt.Errorf("no dishes with calorie information to plan from")
+	}
+	sort.Slice(s.dishes, func(i, j int) bool { return s.dishes[i].Id < s.dishes[j].Id })
+
+	if len(req.PrioritizeIngredients) > 0 {
+		wanted := make(map[string]bool, len(req.PrioritizeIngredients))
+		for _, id := range req.PrioritizeIngredients {
+			wanted[ingredients.CanonicalID(id)] = true
+		}
+		for _, dish := range s.dishes {
+			for _, line := range dish.Ingredients {
+				if wanted[ingredients.Parse(line).CanonicalID] {
+					s.priority[dish.Id]++
+				}
+			}
+		}
+	}
+
+	for _, cuisine := range req.PreferredCuisines {
+		s.preferred[strings.ToLower(strings.TrimSpace(cuisine))] = true
+	}
+
+	resp := &pb.PlanResponse{}
+	for day := 0; day < s.days; day++ {
+		resp.Schedule = append(resp.Schedule, s.planDay(day))
+	}
+
+	if s.budget > 0 && s.spent > s.budget+0.005 {
+		return nil, errOverBudget{cost: s.spent, budget: s.budget}
+	}
+
+	for _, day := range s.cookDays {
+		resp.CookDays = append(resp.CookDays, strconv.Itoa(day))
+	}
+	resp.ShoppingList = s.shoppingList()
+	return resp, nil
+}
+
+// planDay fills one day from leftovers and, if needed, new batches
+func (s *solver) planDay(day int) *pb.DailyMeals {
+	meals := &pb.DailyMeals{DayIndex: int32(day)}
+	remaining := s.target
+	slack := s.target * calorieTolerance
+
+	// Leftovers first, oldest first, so nothing spoils
+	sort.SliceStable(s.batches, func(i, j int) bool { return s.batches[i].expires < s.batches[j].expires })
+	for _, b := range s.batches {
+		if b.remaining == 0 || b.expires < day || len(meals.DishIds) >= maxDishesPerDay {
+			continue
+		}
+		n := min(b.perDay, b.remaining, int32((remaining+slack)/float64(b.dish.Calories)))
+		if n <= 0 {
+			continue
+		}
+		b.remaining -= n
+		remaining -= float64(n * b.dish.Calories)
+		s.eat(meals, b.dish, n, day)
+	}
+
+	for remaining > slack && len(meals.DishIds) < maxDishesPerDay {
+		// Aim each new dish at an even share of what the day still needs
+		share := remaining / float64(max(1, dishesPerDay-len(meals.DishIds)))
+		dish, servings := s.pickDish(day, share, meals)
+		if dish == nil {
+			break
+		}
+
+		// Cook enough for today and the following days the dish keeps for
+		horizon := min(day+int(dish.ShelfLifeDays), day+maxBatchDays-1, s.days-1)
+		extra := int32(horizon-day) * servings
+		if s.budget > 0 {
+			// Shrink the leftover portion rather than blow the budget
+			for extra > 0 && s.spent+float64(servings+extra)*dish.Cost > s.budget {
+				extra -= servings
+			}
+			horizon = day + int(extra/servings)
+		}
+
+		s.spent += float64(servings+extra) * dish.Cost
+		s.cooked[dish.Id]++
+		if len(s.cookDays) == 0 || s.cookDays[len(s.cookDays)-1] != day {
+			s.cookDays = append(s.cookDays, day)
+		}
+		if extra > 0 {
+			s.batches = append(s.batches, &batch{dish: dish, perDay: servings, remaining: extra, expires: horizon})
+		}
+
+		remaining -= float64(servings * dish.Calories)
+		s.eat(meals, dish, servings, day)
+	}
+
+	return meals
+}
+
+// pickDish scores every dish not already on today's menu and returns the
+// best one with the number of servings that comes closest to the calories
+// wanted from it
+func (s *solver) pickDish(day int, wanted float64, meals *pb.DailyMeals) (*pb.Dish, int32) {
+	onMenu := make(map[string]bool, len(meals.DishIds))
+	for _, id := range meals.DishIds {
+		onMenu[id] = true
+	}
+
+	// Spread what is left of the budget evenly over the remaining days
+	allowance := math.Inf(1)
+	if s.budget > 0 {
+		allowance = (s.budget - s.spent) / float64(s.days-day)
+	}
+
+	var best *pb.Dish
+	var bestServings int32
+	bestScore := math.Inf(1)
+	for _, dish := range s.dishes {
+		if onMenu[dish.Id] {
+			continue
+		}
+		servings := servingsFor(wanted, float64(dish.Calories))
+		cost := float64(servings) * dish.Cost
+
+		score := math.Abs(wanted-float64(servings*dish.Calories)) / s.target * 10
+		score += float64(s.cooked[dish.Id]) * 5
+		if last, ok := s.lastEat[dish.Id]; ok && last == day-1 {
+			score += 3
+		}
+		score -= float64(min(int(dish.ShelfLifeDays), maxBatchDays-1)) * 2
+		// Use up expiring pantry stock early, before it spoils
+		if s.cooked[dish.Id] == 0 {
+			score -= float64(s.priority[dish.Id]) * 4
+		}
+		// Lean towards the cuisines the user is in the mood for
+		if s.preferred[strings.ToLower(dish.Cuisine)] {
+			score -= 2
+		}
+		if !math.IsInf(allowance, 1) {
+			if cost > allowance {
+				score += 1000 + cost - allowance
+			}
+			if allowance > 0 {
+				score += cost / allowance * 3
+			}
+		}
+
+		if score < bestScore {
+			best, bestServings, bestScore = dish, servings, score
+		}
+	}
+	return best, bestServings
+}
+
+// servingsFor returns the whole number of servings closest to the calories
+// still needed, never less than one
+func servingsFor(remaining, calories float64) int32 {
+	return int32(max(1, math.Round(remaining/calories)))
+}
+
+// eat records servings of a dish on a day's menu
+func (s *solver) eat(meals *pb.DailyMeals, dish *pb.Dish, servings int32, day int) {
+	for i, id := range meals.DishIds {
+		if id == dish.Id {
+			meals.Servings[i] += servings
+			return
+		}
+	}
+	meals.DishIds = append(meals.DishIds, dish.Id)
+	meals.Servings = append(meals.Servings, servings)
+	s.lastEat[dish.Id] = day
+}
+
+// shoppingList lists each ingredient of the cooked dishes once, merging
+// lines that name the same canonical ingredient
+func (s *solver) shoppingList() []string {
+	var list []string
+	seen := make(map[string]bool)
+	for _, dish := range s.dishes {
+		if s.cooked[dish.Id] == 0 {
+			continue
+		}
+		for _, line := range dish.Ingredients {
+			key := ingredients.Parse(line).CanonicalID
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			list = append(list, line)
+		}
+	}
+	return list
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	pb "spiceroute/proto"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func testDishes() []*pb.Dish {
+	return []*pb.Dish{
+		{Id: "curry", Cuisine: "indian", Calories: 600, Cost: 4, ShelfLifeDays: 3, Ingredients: []string{"500 g chicken", "1 onion"}},
+		{Id: "pasta", Cuisine: "italian", Calories: 700, Cost: 3, ShelfLifeDays: 2, Ingredients: []string{"200 g spaghetti", "2 tomatoes"}},
+		{Id: "salad", Cuisine: "greek", Calories: 300, Cost: 2.5, ShelfLifeDays: 1, Ingredients: []string{"1 cucumber", "100 g feta"}},
+		{Id: "stew", Cuisine: "french", Calories: 500, Cost: 5, ShelfLifeDays: 4, Ingredients: []string{"400 g beef", "2 carrots"}},
+	}
+}
+
+func dishByID(dishes []*pb.Dish) map[string]*pb.Dish {
+	byID := make(map[string]*pb.Dish, len(dishes))
+	for _, d := range dishes {
+		byID[d.Id] = d
+	}
+	return byID
+}
+
+func TestSolveMeetsCalorieTargets(t *testing.T) {
+	dishes := testDishes()
+	plan, err := Solve(&pb.PlanRequest{Days: 7, Dishes: dishes, DailyCalories: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Schedule) != 7 {
+		t.Fatalf("got %d days, want 7", len(plan.Schedule))
+	}
+
+	byID := dishByID(dishes)
+	for _, day := range plan.Schedule {
+		if len(day.DishIds) == 0 || len(day.DishIds) > maxDishesPerDay {
+			t.Errorf("day %d has %d dishes, want 1 to %d", day.DayIndex, len(day.DishIds), maxDishesPerDay)
+		}
+		var calories float64
+		for i, id := range day.DishIds {
+			calories += float64(day.Servings[i] * byID[id].Calories)
+		}
+		if math.Abs(calories-2000) > 2000*calorieTolerance {
+			t.Errorf("day %d has %.0f calories, want 2000 ± %.0f%%", day.DayIndex, calories, calorieTolerance*100)
+		}
+	}
+}
+
+func TestSolveStaysWithinBudget(t *testing.T) {
+	dishes := testDishes()
+	budget := 100.0
+	plan, err := Solve(&pb.PlanRequest{Days: 7, Dishes: dishes, DailyCalories: 2000, BudgetWeek: budget})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Everything eaten was cooked, so its cost is at most what was spent
+	byID := dishByID(dishes)
+	var eaten float64
+	for _, day := range plan.Schedule {
+		for i, id := range day.DishIds {
+			eaten += float64(day.Servings[i]) * byID[id].Cost
+		}
+	}
+	if eaten > budget {
+		t.Errorf("plan eats %.2f worth of food, over the budget of %.2f", eaten, budget)
+	}
+}
+
+func TestSolveOverBudget(t *testing.T) {
+	_, err := Solve(&pb.PlanRequest{Days: 7, Dishes: testDishes(), DailyCalories: 2000, BudgetWeek: 10})
+	var over errOverBudget
+	if !errors.As(err, &over) {
+		t.Fatalf("got error %v, want errOverBudget", err)
+	}
+	if over.budget != 10 || over.cost <= over.budget {
+		t.Errorf("got %+v, want a cost over a budget of 10", over)
+	}
+}
+
+func TestSolveWithoutCalories(t *testing.T) {
+	_, err := Solve(&pb.PlanRequest{Dishes: []*pb.Dish{{Id: "water"}}})
+	if err == nil {
+		t.Fatal("want an error for dishes without calories")
+	}
+}
+
+func TestSolveCooksEveryDayWithoutShelfLife(t *testing.T) {
+	dishes := testDishes()
+	for _, d := range dishes {
+		d.ShelfLifeDays = 0
+	}
+	plan, err := Solve(&pb.PlanRequest{Days: 5, Dishes: dishes, DailyCalories: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.CookDays) != 5 {
+		t.Errorf("got cook days %v, want every day when nothing keeps", plan.CookDays)
+	}
+}
+
+func TestSolvePlansLeftovers(t *testing.T) {
+	plan, err := Solve(&pb.PlanRequest{Days: 7, Dishes: testDishes(), DailyCalories: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.CookDays) >= 7 {
+		t.Errorf("got cook days %v, want leftovers to save some cooking", plan.CookDays)
+	}
+	if len(plan.ShoppingList) == 0 {
+		t.Error("want a shopping list for the cooked dishes")
+	}
+}
+
+func TestSolveLimitsRepetition(t *testing.T) {
+	plan, err := Solve(&pb.PlanRequest{Days: 7, Dishes: testDishes(), DailyCalories: 2000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	streak := make(map[string]int)
+	for _, day := range plan.Schedule {
+		today := make(map[string]bool)
+		for _, id := range day.DishIds {
+			if today[id] {
+				t.Errorf("day %d lists %s twice", day.DayIndex, id)
+			}
+			today[id] = true
+		}
+		for id := range streak {
+			if !today[id] {
+				delete(streak, id)
+			}
+		}
+		for id := range today {
+			streak[id]++
+			if streak[id] > maxBatchDays {
+				t.Errorf("%s is eaten %d days in a row, want at most %d", id, streak[id], maxBatchDays)
+			}
+		}
+	}
+}
+
+func TestSolveIsDeterministic(t *testing.T) {
+	dishes := testDishes()
+	req := &pb.PlanRequest{Days: 7, Dishes: dishes, DailyCalories: 1800, BudgetWeek: 120}
+	first, err := Solve(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The same dishes in another order must give the same plan
+	reversed := make([]*pb.Dish, len(dishes))
+	for i, d := range dishes {
+		reversed[len(dishes)-1-i] = d
+	}
+	second, err := Solve(&pb.PlanRequest{Days: 7, Dishes: reversed, DailyCalories: 1800, BudgetWeek: 120})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(first, second) {
+		t.Errorf("plans differ:\n%v\n%v", first, second)
+	}
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"spiceroute/pkg/config"
+	"spiceroute/pkg/database"
+	"spiceroute/pkg/grpcserver"
+	"spiceroute/pkg/models"
+	"spiceroute/pkg/moods"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+const (
+	dateLayout       = "2006-01-02"
+	defaultListLimit = 20
+)
+
+type server struct {
+	pb.UnimplementedPlanServiceServer
+	db      *gorm.DB
+	planner pb.PlannerServiceClient
+}
+
+func (s *server) SavePlan(ctx context.Context, p *pb.MealPlan) (*pb.MealPlan, error) {
+	if p.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if p.Plan == nil {
+		return nil, status.Error(codes.InvalidArgument, "plan is required")
+	}
+
+	weekStart, err := parseWeekStart(p.WeekStart)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid week_start %q: %v", p.WeekStart, err)
+	}
+
+	plan := models.MealPlan{
+		UserID:       p.UserId,
+		WeekStart:    weekStart,
+		CookDays:     p.Plan.CookDays,
+		ShoppingList: p.Plan.ShoppingList,
+		Entries:      entriesFromProto(p.Plan),
+	}
+	if req := p.Request; req != nil {
+		plan.Days = req.Days
+		plan.DailyCalories = req.DailyCalories
+		plan.BudgetWeek = req.BudgetWeek
+		for _, dish := range req.Dishes {
+			plan.DishIDs = append(plan.DishIDs, dish.Id)
+		}
+	}
+
+	result := s.db.WithContext(ctx).Create(&plan)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return planToProto(plan), nil
+}
+
+func (s *server) ListPlans(ctx context.Context, req *pb.PlanListRequest) (*pb.MealPlanList, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	var plans []models.MealPlan
+	result := preloadEntries(s.db.WithContext(ctx)).
+		Where("user_id = ?", req.UserId).
+		Order("week_start DESC, created_at DESC").
+		Limit(limit).
+		Find(&plans)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var pbPlans []*pb.MealPlan
+	for _, plan := range plans {
+		pbPlans = append(pbPlans, planToProto(plan))
+	}
+
+	return &pb.MealPlanList{Plans: pbPlans}, nil
+}
+
+func (s *server) GetPlan(ctx context.Context, req *pb.PlanLookup) (*pb.MealPlan, error) {
+	plan, err := s.findPlan(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return planToProto(plan), nil
+}
+
+func (s *server) DeletePlan(ctx context.Context, req *pb.PlanLookup) (*emptypb.Empty, error) {
+	if req.UserId == "" || req.PlanId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id and plan id are required")
+	}
+
+	result := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", req.PlanId, req.UserId).
+		Delete(&models.MealPlan{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Errorf(codes.NotFound, "plan %s not found", req.PlanId)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RegeneratePlan runs the planner again with the stored request parameters
+// and the current versions of the plan's dishes, replacing the schedule in
+// place so the plan keeps its ID.
+func (s *server) RegeneratePlan(ctx context.Context, req *pb.PlanLookup) (*pb.MealPlan, error) {
+	plan, err := s.findPlan(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.DishIDs) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "plan %s has no stored dishes to regenerate from", plan.ID)
+	}
+
+	var recipes []models.Recipe
+	result := s.db.WithContext(ctx).Where("id IN ?", plan.DishIDs).Find(&recipes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(recipes) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "none of the dishes in plan %s exist anymore", plan.ID)
+	}
+
+	// Regenerating follows the user's current tastes, this week's mood included
+	cuisines, _, _, err := moods.Effective(ctx, s.db, plan.UserID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	planReq := &pb.PlanRequest{
+		UserId:            plan.UserID,
+		Days:              plan.Days,
+		DailyCalories:     plan.DailyCalories,
+		BudgetWeek:        plan.BudgetWeek,
+		PreferredCuisines: cuisines,
+	}
+	for _, recipe := range recipes {
+		planReq.Dishes = append(planReq.Dishes, &pb.Dish{
+			Id:            recipe.ID,
+			Name:          recipe.Name,
+			Cuisine:       recipe.Cuisine,
+			PrepMinutes:   recipe.PrepMinutes,
+			Calories:      recipe.Calories,
+			Ingredients:   recipe.Ingredients,
+			Cost:          recipe.Cost,
+			ShelfLifeDays: recipe.ShelfLifeDays,
+		})
+	}
+
+	generated, err := s.planner.GeneratePlan(ctx, planReq)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.CookDays = generated.CookDays
+	plan.ShoppingList = generated.ShoppingList
+	entries := entriesFromProto(generated)
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Entries").Save(&plan).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("plan_id = ?", plan.ID).Delete(&models.PlanEntry{}).Error; err != nil {
+			return err
+		}
+		for i := range entries {
+			entries[i].PlanID = plan.ID
+		}
+		if len(entries) > 0 {
+			return tx.Create(&entries).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	plan.Entries = entries
+
+	return planToProto(plan), nil
+}
+
+// findPlan loads a plan with its entries, scoped to the owning user
+func (s *server) findPlan(ctx context.Context, req *pb.PlanLookup) (models.MealPlan, error) {
+	var plan models.MealPlan
+	if req.UserId == "" || req.PlanId == "" {
+		return plan, status.Error(codes.InvalidArgument, "user id and plan id are required")
+	}
+
+	result := preloadEntries(s.db.WithContext(ctx)).
+		Where("id = ? AND user_id = ?", req.PlanId, req.UserId).
+		First(&plan)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return plan, status.Errorf(codes.NotFound, "plan %s not found", req.PlanId)
+		}
+		return plan, result.Error
+	}
+
+	return plan, nil
+}
+
+// preloadEntries loads plan entries in schedule order
+func preloadEntries(db *gorm.DB) *gorm.DB {
+	return db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("day_index, position")
+	})
+}
+
+// parseWeekStart parses a YYYY-MM-DD date and moves it back to its Monday,
+// the same week moods use, defaulting to this week's Monday
+func parseWeekStart(value string) (time.Time, error) {
+	if value == "" {
+		return moods.WeekStart(time.Now()), nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return moods.WeekStart(t), nil
+}
+
+// entriesFromProto flattens a plan schedule into one entry per scheduled dish
+func entriesFromProto(resp *pb.PlanResponse) []models.PlanEntry {
+	var entries []models.PlanEntry
+	for _, day := range resp.Schedule {
+		for i, dishID := range day.DishIds {
+			servings := int32(1)
+			if i < len(day.Servings) {
+				servings = day.Servings[i]
+			}
+			entries = append(entries, models.PlanEntry{
+				DayIndex: day.DayIndex,
+				Position: int32(i),
+				DishID:   dishID,
+				Servings: servings,
+			})
+		}
+	}
+	return entries
+}
+
+// planToProto converts a plan model, with its entries grouped back into
+// days, to its protobuf representation
+func planToProto(plan models.MealPlan) *pb.MealPlan {
+	resp := &pb.PlanResponse{
+		CookDays:     plan.CookDays,
+		ShoppingList: plan.ShoppingList,
+	}
+	var day *pb.DailyMeals
+	for _, entry := range plan.Entries {
+		if day == nil || day.DayIndex != entry.DayIndex {
+			day = &pb.DailyMeals{DayIndex: entry.DayIndex}
+			resp.Schedule = append(resp.Schedule, day)
+		}
+		day.DishIds = append(day.DishIds, entry.DishID)
+		day.Servings = append(day.Servings, entry.Servings)
+	}
+
+	req := &pb.PlanRequest{
+		UserId:        plan.UserID,
+		Days:          plan.Days,
+		DailyCalories: plan.DailyCalories,
+		BudgetWeek:    plan.BudgetWeek,
+	}
+	for _, id := range plan.DishIDs {
+		req.Dishes = append(req.Dishes, &pb.Dish{Id: id})
+	}
+
+	return &pb.MealPlan{
+		Id:        plan.ID,
+		UserId:    plan.UserID,
+		WeekStart: plan.WeekStart.Format(dateLayout),
+		Request:   req,
+		Plan:      resp,
+		CreatedAt: plan.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func main() {
+	cfg, err := config.Load("plans")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	// Initialize database connection
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	// Refuse to start against a schema older than this build
+	if err := database.VerifySchema(db); err != nil {
+		log.Fatal("Database schema check failed:", err)
+	}
+
+	// Connect to the planner for regeneration
+	plannerConn, err := grpc.NewClient(cfg.Address("planner"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatal("Failed to create planner client:", err)
+	}
+	defer plannerConn.Close()
+
+	// Start gRPC server
+	grpcServer := grpcserver.NewServer()
+	pb.RegisterPlanServiceServer(grpcServer, &server{
+		db:      db,
+		planner: pb.NewPlannerServiceClient(plannerConn),
+	})
+
+	log.Printf("Plan service starting on %s", cfg.ListenAddr())
+	if err := grpcserver.Serve(grpcServer, cfg, db); err != nil {
+		log.Fatal("Failed to serve:", err)
+	}
+}
@@ -2,14 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
-	"net"
+	"slices"
 
+	"spiceroute/pkg/config"
 	"spiceroute/pkg/database"
+	"spiceroute/pkg/grpcserver"
 	"spiceroute/pkg/models"
 	pb "spiceroute/proto"
 
-	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
@@ -18,32 +22,73 @@ type server struct {
 	db *gorm.DB
 }
 
+// UpsertPreference creates or updates a user's preferences. With an
+// update mask only the named fields of an existing preference are written.
 func (s *server) UpsertPreference(ctx context.Context, p *pb.Preference) (*pb.Preference, error) {
-	preference := models.Preference{
-		UserID:     p.UserId,
-		Cuisines:   p.Cuisines,
-		Allergies:  p.Allergies,
-		BudgetWeek: p.BudgetWeek,
-		Spicy:      p.Spicy,
+	if _, err := s.findUser(ctx, p.UserId); err != nil {
+		return nil, err
 	}
 
-	// Use Upsert (Create or Update)
-	result := s.db.WithContext(ctx).Where("user_id = ?", p.UserId).
-		Assign(preference).
-		FirstOrCreate(&preference)
+	columns, err := preferenceColumns(p.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+	preference, err := preferenceFromProto(p, columns)
+	if err != nil {
+		return nil, err
+	}
 
-	if result.Error != nil {
-		return nil, result.Error
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.Preference
+		result := tx.Where("user_id = ?", p.UserId).First(&existing)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			if err := checkHousehold(&preference); err != nil {
+				return err
+			}
+			return tx.Create(&preference).Error
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// The household size and members must agree once the mask is
+		// applied, whichever of them the update writes
+		merged := existing
+		if slices.Contains(columns, "household_size") {
+			merged.HouseholdSize = preference.HouseholdSize
+		}
+		if slices.Contains(columns, "members") {
+			merged.Members = preference.Members
+		}
+		if err := checkHousehold(&merged); err != nil {
+			return err
+		}
+		if merged.HouseholdSize != existing.HouseholdSize && !slices.Contains(columns, "household_size") {
+			columns = append(slices.Clone(columns), "household_size")
+		}
+		preference.HouseholdSize = merged.HouseholdSize
+
+		// Setting spicy alone keeps a level that is already spicy rather
+		// than resetting it to spicyLevel
+		paths := p.UpdateMask.GetPaths()
+		if slices.Contains(paths, "spicy") && !slices.Contains(paths, "spice_level") &&
+			preference.Spicy && existing.SpiceLevel >= spicyLevel {
+			preference.SpiceLevel = existing.SpiceLevel
+		}
+
+		// Select writes the chosen columns even when they hold zero values
+		result = tx.Model(&existing).Select(columns).Updates(&preference)
+		if result.Error != nil {
+			return result.Error
+		}
+		preference = existing
+		return tx.First(&preference, existing.ID).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Convert back to protobuf
-	return &pb.Preference{
-		UserId:     preference.UserID,
-		Cuisines:   preference.Cuisines,
-		Allergies:  preference.Allergies,
-		BudgetWeek: preference.BudgetWeek,
-		Spicy:      preference.Spicy,
-	}, nil
+	return preferenceToProto(preference), nil
 }
 
 func (s *server) GetPreference(ctx context.Context, p *pb.Preference) (*pb.Preference, error) {
@@ -51,43 +96,38 @@ func (s *server) GetPreference(ctx context.Context, p *pb.Preference) (*pb.Prefe
 
 	result := s.db.WithContext(ctx).Where("user_id = ?", p.UserId).First(&preference)
 	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return nil, nil // Return nil if not found
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "no preferences for user %s", p.UserId)
 		}
 		return nil, result.Error
 	}
 
-	// Convert to protobuf
-	return &pb.Preference{
-		UserId:     preference.UserID,
-		Cuisines:   preference.Cuisines,
-		Allergies:  preference.Allergies,
-		BudgetWeek: preference.BudgetWeek,
-		Spicy:      preference.Spicy,
-	}, nil
+	return preferenceToProto(preference), nil
 }
 
 func main() {
+	cfg, err := config.Load("profile")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
 	// Initialize database connection
-	db, err := database.NewConnection()
+	db, err := database.NewConnection(cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	// Run migrations
-	if err := database.AutoMigrate(db); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+	// Refuse to start against a schema older than this build
+	if err := database.VerifySchema(db); err != nil {
+		log.Fatal("Database schema check failed:", err)
 	}
 
 	// Start gRPC server
-	lis, err := net.Listen("tcp", ":50051")
-	if err != nil {
-		log.Fatal("Failed to listen:", err)
-	}
-
-	grpcServer := grpc.NewServer()
+	grpcServer := grpcserver.NewServer()
 	pb.RegisterProfileServiceServer(grpcServer, &server{db: db})
 
-	log.Println("Profile service starting on :50051")
-	log.Fatal(grpcServer.Serve(lis))
+	log.Printf("Profile service starting on %s", cfg.ListenAddr())
+	if err := grpcserver.Serve(grpcServer, cfg, db); err != nil {
+		log.Fatal("Failed to serve:", err)
+	}
 }
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"spiceroute/pkg/models"
+	"spiceroute/pkg/moods"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+const dateLayout = "2006-01-02"
+
+// SetMood sets the user's cuisines for one week, replacing any mood already
+// set for that week
+func (s *server) SetMood(ctx context.Context, m *pb.Mood) (*pb.Mood, error) {
+	if _, err := s.findUser(ctx, m.UserId); err != nil {
+		return nil, err
+	}
+	cuisines := normalizeCuisines(m.CuisinesThisWeek)
+	if len(cuisines) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a mood needs at least one cuisine")
+	}
+	weekStart, err := parseWeekStart(m.WeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := weekStart.AddDate(0, 0, 7)
+	if m.ExpiresAt != "" {
+		if expiresAt, err = time.Parse(time.RFC3339, m.ExpiresAt); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid expires_at %q: %v", m.ExpiresAt, err)
+		}
+	}
+	if !expiresAt.After(weekStart) || !expiresAt.After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future and after week_start")
+	}
+
+	mood := models.Mood{
+		UserID:    m.UserId,
+		WeekStart: weekStart,
+		Cuisines:  cuisines,
+		Exclusive: m.Exclusive,
+		ExpiresAt: expiresAt,
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.Mood
+		result := tx.Where("user_id = ? AND week_start = ?", m.UserId, weekStart).First(&existing)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return tx.Create(&mood).Error
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+		mood.ID = existing.ID
+		mood.CreatedAt = existing.CreatedAt
+		return tx.Save(&mood).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moodToProto(mood), nil
+}
+
+// GetMood returns the mood in effect for the user right now
+func (s *server) GetMood(ctx context.Context, ref *pb.UserRef) (*pb.Mood, error) {
+	mood, err := moods.Current(ctx, s.db, ref.UserId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if mood == nil {
+		return nil, status.Errorf(codes.NotFound, "user %s has no mood this week", ref.UserId)
+	}
+	return moodToProto(*mood), nil
+}
+
+// ClearMood removes the mood of one week, the current week by default
+func (s *server) ClearMood(ctx context.Context, m *pb.Mood) (*emptypb.Empty, error) {
+	weekStart, err := parseWeekStart(m.WeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.db.WithContext(ctx).
+		Where("user_id = ? AND week_start = ?", m.UserId, weekStart).
+		Delete(&models.Mood{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Errorf(codes.NotFound, "user %s has no mood for the week of %s", m.UserId, weekStart.Format(dateLayout))
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// GetEffectiveCuisines returns the cuisines recipe listing and planning use
+// for the user: their preferred cuisines blended with this week's mood
+func (s *server) GetEffectiveCuisines(ctx context.Context, ref *pb.UserRef) (*pb.EffectiveCuisines, error) {
+	if _, err := s.findUser(ctx, ref.UserId); err != nil {
+		return nil, err
+	}
+
+	cuisines, preferred, mood, err := moods.Effective(ctx, s.db, ref.UserId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	effective := &pb.EffectiveCuisines{
+		UserId:    ref.UserId,
+		Cuisines:  cuisines,
+		Preferred: preferred,
+	}
+	if mood != nil {
+		effective.Mood = moodToProto(*mood)
+	}
+	return effective, nil
+}
+
+// parseWeekStart parses a YYYY-MM-DD date and moves it back to its
+// Monday, defaulting to the current week
+func parseWeekStart(value string) (time.Time, error) {
+	if value == "" {
+		return moods.WeekStart(time.Now()), nil
+	}
+	day, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return day, status.Errorf(codes.InvalidArgument, "invalid week_start %q: %v", value, err)
+	}
+	return moods.WeekStart(day), nil
+}
+
+// normalizeCuisines trims cuisines and drops blanks and repeats
+func normalizeCuisines(cuisines []string) []string {
+	return moods.Blend(cuisines, nil)
+}
+
+// moodToProto converts a mood to protobuf
+func moodToProto(mood models.Mood) *pb.Mood {
+	return &pb.Mood{
+		UserId:           mood.UserID,
+		CuisinesThisWeek: mood.Cuisines,
+		WeekStart:        mood.WeekStart.Format(dateLayout),
+		ExpiresAt:        mood.ExpiresAt.UTC().Format(time.RFC3339),
+		Exclusive:        mood.Exclusive,
+	}
+}
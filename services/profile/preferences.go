@@ -0,0 +1,169 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var (
+	diets       = []string{"vegetarian", "vegan", "pescatarian", "halal", "kosher", "keto"}
+	skillLevels = []string{"beginner", "intermediate", "advanced"}
+)
+
+const (
+	maxSpiceLevel = 5
+	// spicyLevel is the spice level at which the legacy spicy flag is set
+	spicyLevel = 3
+)
+
+// preferenceFields are the Preference fields an update mask may name. Each
+// path is also the column it is stored in.
+var preferenceFields = []string{
+	"cuisines",
+	"allergies",
+	"budget_week",
+	"spicy",
+	"diet",
+	"disliked_ingredients",
+	"household_size",
+	"members",
+	"spice_level",
+	"max_weeknight_prep_minutes",
+	"skill_level",
+}
+
+// preferenceColumns returns the columns an upsert writes: those named by
+// mask, or every preference column when the mask is empty. spicy and
+// spice_level are always written together so they stay in step.
+func preferenceColumns(mask *fieldmaskpb.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return preferenceFields, nil
+	}
+
+	var columns []string
+	for _, path := range mask.GetPaths() {
+		if !slices.Contains(preferenceFields, path) {
+			return nil, status.Errorf(codes.InvalidArgument, "update mask names unknown field %q", path)
+		}
+		if !slices.Contains(columns, path) {
+			columns = append(columns, path)
+		}
+	}
+	for _, pair := range [][2]string{{"spicy", "spice_level"}, {"spice_level", "spicy"}} {
+		if slices.Contains(columns, pair[0]) && !slices.Contains(columns, pair[1]) {
+			columns = append(columns, pair[1])
+		}
+	}
+	return columns, nil
+}
+
+// preferenceFromProto validates and normalizes the fields of p that will
+// be written and converts it to a model
+func preferenceFromProto(p *pb.Preference, columns []string) (models.Preference, error) {
+	writes := func(field string) bool { return slices.Contains(columns, field) }
+
+	preference := models.Preference{
+		UserID:                  p.UserId,
+		Cuisines:                p.Cuisines,
+		Allergies:               p.Allergies,
+		BudgetWeek:              p.BudgetWeek,
+		Diet:                    strings.ToLower(strings.TrimSpace(p.Diet)),
+		DislikedIngredients:     normalizeNames(p.DislikedIngredients),
+		HouseholdSize:           p.HouseholdSize,
+		SpiceLevel:              p.SpiceLevel,
+		MaxWeeknightPrepMinutes: p.MaxWeeknightPrepMinutes,
+		SkillLevel:              strings.ToLower(strings.TrimSpace(p.SkillLevel)),
+	}
+	for _, m := range p.Members {
+		preference.Members = append(preference.Members, models.HouseholdMember{
+			Name:          strings.TrimSpace(m.Name),
+			DailyCalories: m.DailyCalories,
+		})
+	}
+
+	if writes("budget_week") && preference.BudgetWeek < 0 {
+		return preference, status.Error(codes.InvalidArgument, "budget_week must not be negative")
+	}
+	if writes("diet") && preference.Diet != "" && !slices.Contains(diets, preference.Diet) {
+		return preference, status.Errorf(codes.InvalidArgument, "unknown diet %q, expected one of %s", p.Diet, strings.Join(diets, ", "))
+	}
+	if writes("skill_level") && preference.SkillLevel != "" && !slices.Contains(skillLevels, preference.SkillLevel) {
+		return preference, status.Errorf(codes.InvalidArgument, "unknown skill level %q, expected one of %s", p.SkillLevel, strings.Join(skillLevels, ", "))
+	}
+	if writes("max_weeknight_prep_minutes") && preference.MaxWeeknightPrepMinutes < 0 {
+		return preference, status.Error(codes.InvalidArgument, "max_weeknight_prep_minutes must not be negative")
+	}
+	if writes("household_size") && preference.HouseholdSize < 0 {
+		return preference, status.Error(codes.InvalidArgument, "household_size must not be negative")
+	}
+	for _, m := range preference.Members {
+		if m.DailyCalories < 0 {
+			return preference, status.Errorf(codes.InvalidArgument, "member %q has a negative calorie target", m.Name)
+		}
+	}
+
+	// Older clients only send spicy; newer ones send spice_level
+	if writes("spice_level") && (preference.SpiceLevel < 0 || preference.SpiceLevel > maxSpiceLevel) {
+		return preference, status.Errorf(codes.InvalidArgument, "spice_level must be between 0 and %d", maxSpiceLevel)
+	}
+	if p.Spicy && p.SpiceLevel == 0 {
+		preference.SpiceLevel = spicyLevel
+	}
+	preference.Spicy = preference.SpiceLevel >= spicyLevel
+
+	return preference, nil
+}
+
+// checkHousehold validates the household size of a preference as it will be
+// stored against its members, filling in a missing size from the members
+func checkHousehold(preference *models.Preference) error {
+	if len(preference.Members) == 0 {
+		return nil
+	}
+	if preference.HouseholdSize == 0 {
+		preference.HouseholdSize = int32(len(preference.Members))
+	}
+	if int(preference.HouseholdSize) < len(preference.Members) {
+		return status.Errorf(codes.InvalidArgument, "household of %d cannot have %d members", preference.HouseholdSize, len(preference.Members))
+	}
+	return nil
+}
+
+// preferenceToProto converts a preference to protobuf
+func preferenceToProto(preference models.Preference) *pb.Preference {
+	p := &pb.Preference{
+		UserId:                  preference.UserID,
+		Cuisines:                preference.Cuisines,
+		Allergies:               preference.Allergies,
+		BudgetWeek:              preference.BudgetWeek,
+		Spicy:                   preference.Spicy,
+		Diet:                    preference.Diet,
+		DislikedIngredients:     preference.DislikedIngredients,
+		HouseholdSize:           preference.HouseholdSize,
+		SpiceLevel:              preference.SpiceLevel,
+		MaxWeeknightPrepMinutes: preference.MaxWeeknightPrepMinutes,
+		SkillLevel:              preference.SkillLevel,
+	}
+	for _, m := range preference.Members {
+		p.Members = append(p.Members, &pb.HouseholdMember{Name: m.Name, DailyCalories: m.DailyCalories})
+	}
+	return p
+}
+
+// normalizeNames lowercases and trims ingredient names, dropping blanks
+func normalizeNames(names []string) []string {
+	var out []string
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+// CreateUser registers a user account. The id may be supplied, so accounts
+// can match the subject of an external identity provider's tokens.
+func (s *server) CreateUser(ctx context.Context, u *pb.User) (*pb.User, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(u.Email))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid email %q", u.Email)
+	}
+	// Store the bare address, without any display name, in lower case
+	email := strings.ToLower(addr.Address)
+
+	var taken int64
+	result := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("lower(email) = lower(?)", email).
+		Count(&taken)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if taken > 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "a user with email %s already exists", email)
+	}
+
+	user := models.User{ID: u.Id, Email: email, Name: strings.TrimSpace(u.Name)}
+	if user.ID != "" {
+		// Unscoped, so a deleted account's id is not handed out again
+		result = s.db.WithContext(ctx).Unscoped().Model(&models.User{}).Where("id = ?", user.ID).Count(&taken)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if taken > 0 {
+			return nil, status.Errorf(codes.AlreadyExists, "user %s already exists", user.ID)
+		}
+	}
+
+	result = s.db.WithContext(ctx).Create(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return userToProto(user), nil
+}
+
+func (s *server) GetUser(ctx context.Context, ref *pb.UserRef) (*pb.User, error) {
+	user, err := s.findUser(ctx, ref.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return userToProto(user), nil
+}
+
+// DeleteUser soft deletes a user together with everything they own, so the
+// account disappears from every service at once
+func (s *server) DeleteUser(ctx context.Context, ref *pb.UserRef) (*emptypb.Empty, error) {
+	user, err := s.findUser(ctx, ref.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		owned := []interface{}{
+			&models.Preference{},
+			&models.Feedback{},
+			&models.MealPlan{},
+			&models.ShoppingList{},
+			&models.PantryItem{},
+			&models.Mood{},
+		}
+		for _, model := range owned {
+			if err := tx.Where("user_id = ?", user.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&user).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// userExport is the document returned by ExportUserData
+type userExport struct {
+	User          exportedUser           `json:"user"`
+	Preferences   []exportedPreference   `json:"preferences"`
+	Feedback      []exportedFeedback     `json:"feedback"`
+	Plans         []exportedPlan         `json:"plans"`
+	ShoppingLists []exportedShoppingList `json:"shopping_lists"`
+	Pantry        []exportedPantryItem   `json:"pantry"`
+	Moods         []exportedMood         `json:"moods"`
+	ExportedAt    time.Time              `json:"exported_at"`
+}
+
+type exportedUser struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type exportedPreference struct {
+	Cuisines                []string                 `json:"cuisines"`
+	Allergies               []string                 `json:"allergies"`
+	BudgetWeek              float64                  `json:"budget_week"`
+	Spicy                   bool                     `json:"spicy"`
+	Diet                    string                   `json:"diet"`
+	DislikedIngredients     []string                 `json:"disliked_ingredients"`
+	HouseholdSize           int32                    `json:"household_size"`
+	Members                 []models.HouseholdMember `json:"members"`
+	SpiceLevel              int32                    `json:"spice_level"`
+	MaxWeeknightPrepMinutes int32                    `json:"max_weeknight_prep_minutes"`
+	SkillLevel              string                   `json:"skill_level"`
+	UpdatedAt               time.Time                `json:"updated_at"`
+}
+
+type exportedFeedback struct {
+	DishID          string    `json:"dish_id"`
+	Rating          int32     `json:"rating"`
+	Skipped         bool      `json:"skipped"`
+	SubstitutedWith string    `json:"substituted_with"`
+	Comment         string    `json:"comment"`
+	CookedAt        time.Time `json:"cooked_at"`
+}
+
+type exportedPlan struct {
+	ID            string              `json:"id"`
+	WeekStart     string              `json:"week_start"`
+	Days          int32               `json:"days"`
+	DailyCalories float64             `json:"daily_calories"`
+	BudgetWeek    float64             `json:"budget_week"`
+	CookDays      []string            `json:"cook_days"`
+	ShoppingList  []string            `json:"shopping_list"`
+	Entries       []exportedPlanEntry `json:"entries"`
+	CreatedAt     time.Time           `json:"created_at"`
+}
+
+type exportedPlanEntry struct {
+	DayIndex int32  `json:"day_index"`
+	DishID   string `json:"dish_id"`
+	Servings int32  `json:"servings"`
+}
+
+type exportedShoppingList struct {
+	ID        string                 `json:"id"`
+	PlanID    *string                `json:"plan_id"`
+	Items     []exportedShoppingItem `json:"items"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type exportedShoppingItem struct {
+	Name     string  `json:"name"`
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"`
+	Category string  `json:"category"`
+	Checked  bool    `json:"checked"`
+	Manual   bool    `json:"manual"`
+}
+
+type exportedMood struct {
+	WeekStart string    `json:"week_start"`
+	Cuisines  []string  `json:"cuisines"`
+	Exclusive bool      `json:"exclusive"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type exportedPantryItem struct {
+	Name      string     `json:"name"`
+	Quantity  float64    `json:"quantity"`
+	Unit      string     `json:"unit"`
+	ExpiresOn *time.Time `json:"expires_on"`
+}
+
+// ExportUserData gathers everything stored about a user into one JSON
+// document, for data access requests
+func (s *server) ExportUserData(ctx context.Context, ref *pb.UserRef) (*pb.UserDataExport, error) {
+	user, err := s.findUser(ctx, ref.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.db.WithContext(ctx)
+	var preferences []models.Preference
+	var feedback []models.Feedback
+	var plans []models.MealPlan
+	var lists []models.ShoppingList
+	var pantry []models.PantryItem
+	var userMoods []models.Mood
+	queries := []*gorm.DB{
+		db.Where("user_id = ?", user.ID).Find(&preferences),
+		db.Where("user_id = ?", user.ID).Order("cooked_at").Find(&feedback),
+		db.Preload("Entries", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("day_index, position")
+		}).Where("user_id = ?", user.ID).Order("week_start").Find(&plans),
+		db.Preload("Items").Where("user_id = ?", user.ID).Order("created_at").Find(&lists),
+		db.Where("user_id = ?", user.ID).Order("name").Find(&pantry),
+		db.Where("user_id = ?", user.ID).Order("week_start").Find(&userMoods),
+	}
+	for _, q := range queries {
+		if q.Error != nil {
+			return nil, q.Error
+		}
+	}
+
+	now := time.Now().UTC()
+	export := userExport{
+		User: exportedUser{
+			ID:        user.ID,
+			Email:     user.Email,
+			Name:      user.Name,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		},
+		Preferences:   []exportedPreference{},
+		Feedback:      []exportedFeedback{},
+		Plans:         []exportedPlan{},
+		ShoppingLists: []exportedShoppingList{},
+		Pantry:        []exportedPantryItem{},
+		Moods:         []exportedMood{},
+		ExportedAt:    now,
+	}
+	for _, p := range preferences {
+		export.Preferences = append(export.Preferences, exportedPreference{
+			Cuisines:                p.Cuisines,
+			Allergies:               p.Allergies,
+			BudgetWeek:              p.BudgetWeek,
+			Spicy:                   p.Spicy,
+			Diet:                    p.Diet,
+			DislikedIngredients:     p.DislikedIngredients,
+			HouseholdSize:           p.HouseholdSize,
+			Members:                 p.Members,
+			SpiceLevel:              p.SpiceLevel,
+			MaxWeeknightPrepMinutes: p.MaxWeeknightPrepMinutes,
+			SkillLevel:              p.SkillLevel,
+			UpdatedAt:               p.UpdatedAt,
+		})
+	}
+	for _, f := range feedback {
+		export.Feedback = append(export.Feedback, exportedFeedback{
+			DishID:          f.DishID,
+			Rating:          f.Rating,
+			Skipped:         f.Skipped,
+			SubstitutedWith: f.SubstitutedWith,
+			Comment:         f.Comment,
+			CookedAt:        f.CookedAt,
+		})
+	}
+	for _, p := range plans {
+		plan := exportedPlan{
+			ID:            p.ID,
+			WeekStart:     p.WeekStart.Format(dateLayout),
+			Days:          p.Days,
+			DailyCalories: p.DailyCalories,
+			BudgetWeek:    p.BudgetWeek,
+			CookDays:      p.CookDays,
+			ShoppingList:  p.ShoppingList,
+			CreatedAt:     p.CreatedAt,
+		}
+		for _, e := range p.Entries {
+			plan.Entries = append(plan.Entries, exportedPlanEntry{DayIndex: e.DayIndex, DishID: e.DishID, Servings: e.Servings})
+		}
+		export.Plans = append(export.Plans, plan)
+	}
+	for _, l := range lists {
+		list := exportedShoppingList{ID: l.ID, PlanID: l.PlanID, CreatedAt: l.CreatedAt}
+		for _, item := range l.Items {
+			list.Items = append(list.Items, exportedShoppingItem{
+				Name:     item.Name,
+				Quantity: item.Quantity,
+				Unit:     item.Unit,
+				Category: item.Category,
+				Checked:  item.Checked,
+				Manual:   item.Manual,
+			})
+		}
+		export.ShoppingLists = append(export.ShoppingLists, list)
+	}
+	for _, item := range pantry {
+		export.Pantry = append(export.Pantry, exportedPantryItem{
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			Unit:      item.Unit,
+			ExpiresOn: item.ExpiresOn,
+		})
+	}
+
+	for _, m := range userMoods {
+		export.Moods = append(export.Moods, exportedMood{
+			WeekStart: m.WeekStart.Format(dateLayout),
+			Cuisines:  m.Cuisines,
+			Exclusive: m.Exclusive,
+			ExpiresAt: m.ExpiresAt,
+		})
+	}
+
+	document, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UserDataExport{
+		UserId:     user.ID,
+		Document:   document,
+		ExportedAt: now.Format(time.RFC3339),
+	}, nil
+}
+
+// findUser loads an active user account
+func (s *server) findUser(ctx context.Context, userID string) (models.User, error) {
+	var user models.User
+	if userID == "" {
+		return user, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	result := s.db.WithContext(ctx).Where("id = ?", userID).First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return user, status.Errorf(codes.NotFound, "user %s not found", userID)
+		}
+		return user, result.Error
+	}
+	return user, nil
+}
+
+// userToProto converts a user account to protobuf
+func userToProto(user models.User) *pb.User {
+	return &pb.User{
+		Id:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		CreatedAt: user.CreatedAt.Format(time.RFC3339),
+	}
+}
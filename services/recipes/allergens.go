@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"spiceroute/pkg/allergens"
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (s *server) CheckAllergens(ctx context.Context, req *pb.AllergenCheckRequest) (*pb.AllergenReport, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if len(req.RecipeIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one recipe id is required")
+	}
+
+	allergies, err := s.userAllergies(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipes []models.Recipe
+	result := s.db.WithContext(ctx).Where("id IN ?", req.RecipeIds).Find(&recipes)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	byID := make(map[string]models.Recipe, len(recipes))
+	for _, recipe := range recipes {
+		byID[recipe.ID] = recipe
+	}
+
+	report := &pb.AllergenReport{Allergies: allergies}
+	for _, id := range req.RecipeIds {
+		recipe, ok := byID[id]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "recipe %s not found", id)
+		}
+
+		entry := &pb.RecipeAllergens{RecipeId: id}
+		for _, m := range allergens.Find(allergies, recipe.Ingredients) {
+			entry.Matches = append(entry.Matches, &pb.AllergenMatch{
+				Allergen:   m.Allergen,
+				Ingredient: m.Ingredient,
+			})
+		}
+		entry.Safe = len(entry.Matches) == 0
+		report.Recipes = append(report.Recipes, entry)
+	}
+
+	return report, nil
+}
+
+// userAllergies loads the allergies from a user's stored preference. A user
+// who has not onboarded yet has declared no allergies.
+func (s *server) userAllergies(ctx context.Context, userID string) ([]string, error) {
+	var preference models.Preference
+	result := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&preference)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return preference.Allergies, nil
+}
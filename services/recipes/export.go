@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	markdownTemplate = texttemplate.Must(texttemplate.New("recipes.md.tmpl").Funcs(templateFuncs).ParseFS(templateFS, "templates/recipes.md.tmpl"))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("recipes.html.tmpl").Funcs(templateFuncs).ParseFS(templateFS, "templates/recipes.html.tmpl"))
+
+	templateFuncs = map[string]interface{}{
+		"join": strings.Join,
+		"amount": func(v float64) string {
+			return fmt.Sprintf("%g", roundTenth(v))
+		},
+	}
+
+	nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// exportFormats lists the supported formats with their content type and
+// file extension
+var exportFormats = map[string]struct{ contentType, ext string }{
+	"jsonld":   {"application/ld+json", ".jsonld"},
+	"markdown": {"text/markdown; charset=utf-8", ".md"},
+	"html":     {"text/html; charset=utf-8", ".html"},
+}
+
+// cookbook is what the templates render: a title and the recipes in order
+type cookbook struct {
+	Title   string
+	Recipes []recipeCard
+}
+
+// recipeCard is a recipe prepared for display, with nutrition per serving
+type recipeCard struct {
+	ID          string
+	Name        string
+	Cuisine     string
+	PrepMinutes int32
+	Calories    int32
+	Cost        float64
+	Servings    int32
+	Ingredients []string
+	Tags        []string
+	Nutrition   []nutrient
+}
+
+type nutrient struct {
+	Name     string
+	Property string // schema.org NutritionInformation property
+	Amount   float64
+	Unit     string
+}
+
+// ExportRecipes renders recipes, or every recipe of a meal plan as one
+// cookbook, as schema.org JSON-LD, Markdown or printable HTML
+func (s *server) ExportRecipes(ctx context.Context, req *pb.RecipeExportRequest) (*pb.RecipeExport, error) {
+	format := req.Format
+	if format == "" {
+		format = "jsonld"
+	}
+	spec, ok := exportFormats[format]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown export format %q, expected jsonld, markdown or html", req.Format)
+	}
+
+	var book cookbook
+	var err error
+	switch {
+	case req.PlanId != "":
+		book, err = s.planCookbook(ctx, req.UserId, req.PlanId)
+	case len(req.RecipeIds) > 0:
+		book, err = s.recipeCookbook(ctx, req.RecipeIds, false)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "recipe ids or a plan id are required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "jsonld":
+		err = writeJSONLD(&buf, book, req.PlanId != "")
+	case "markdown":
+		err = markdownTemplate.Execute(&buf, book)
+	case "html":
+		err = htmlTemplate.Execute(&buf, book)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RecipeExport{
+		Format:      format,
+		ContentType: spec.contentType,
+		Filename:    slug(book.Title) + spec.ext,
+		Document:    buf.Bytes(),
+	}, nil
+}
+
+// recipeCookbook loads recipes by id, keeping the requested order. With
+// includeDeleted, soft-deleted recipes are loaded too.
+func (s *server) recipeCookbook(ctx context.Context, ids []string, includeDeleted bool) (cookbook, error) {
+	db := s.reader.WithContext(ctx)
+	if includeDeleted {
+		db = db.Unscoped()
+	}
+	var recipes []models.Recipe
+	result := preloadIngredients(db).Where("id IN ?", ids).Find(&recipes)
+	if result.Error != nil {
+		return cookbook{}, result.Error
+	}
+	byID := make(map[string]models.Recipe, len(recipes))
+	for _, r := range recipes {
+		byID[r.ID] = r
+	}
+
+	book := cookbook{Title: "SpiceRoute recipes"}
+	for _, id := range ids {
+		recipe, ok := byID[id]
+		if !ok {
+			return book, status.Errorf(codes.NotFound, "recipe %s not found", id)
+		}
+		book.Recipes = append(book.Recipes, newRecipeCard(recipe))
+	}
+	if len(book.Recipes) == 1 {
+		book.Title = book.Recipes[0].Name
+	}
+	return book, nil
+}
+
+// planCookbook collects the recipes of a user's meal plan in the order
+// they are first eaten
+func (s *server) planCookbook(ctx context.Context, userID, planID string) (cookbook, error) {
+	if userID == "" {
+		return cookbook{}, status.Error(codes.InvalidArgument, "user id is required with a plan id")
+	}
+
+	var plan models.MealPlan
+	result := s.reader.WithContext(ctx).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB { return db.Order("day_index, position") }).
+		Where("id = ? AND user_id = ?", planID, userID).
+		First(&plan)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return cookbook{}, status.Errorf(codes.NotFound, "plan %s not found", planID)
+		}
+		return cookbook{}, result.Error
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, entry := range plan.Entries {
+		if !seen[entry.DishID] {
+			seen[entry.DishID] = true
+			ids = append(ids, entry.DishID)
+		}
+	}
+	if len(ids) == 0 {
+		return cookbook{}, status.Errorf(codes.FailedPrecondition, "plan %s has no recipes", planID)
+	}
+
+	// A plan keeps the recipes it was made with, even ones since deleted
+	// from the catalog
+	book, err := s.recipeCookbook(ctx, ids, true)
+	book.Title = "Meal plan for the week of " + plan.WeekStart.Format("2006-01-02")
+	return book, err
+}
+
+// Meta summarizes cuisine, time, calories, servings and cost for display
+func (c recipeCard) Meta() []string {
+	var meta []string
+	if c.Cuisine != "" {
+		meta = append(meta, c.Cuisine)
+	}
+	if c.PrepMinutes > 0 {
+		meta = append(meta, fmt.Sprintf("%d min", c.PrepMinutes))
+	}
+	if c.Calories > 0 {
+		meta = append(meta, fmt.Sprintf("%d kcal per serving", c.Calories))
+	}
+	if c.Servings > 0 {
+		meta = append(meta, fmt.Sprintf("serves %d", c.Servings))
+	}
+	if c.Cost > 0 {
+		meta = append(meta, fmt.Sprintf("cost %.2f", c.Cost))
+	}
+	return meta
+}
+
+func newRecipeCard(recipe models.Recipe) recipeCard {
+	card := recipeCard{
+		ID:          recipe.ID,
+		Name:        recipe.Name,
+		Cuisine:     recipe.Cuisine,
+		PrepMinutes: recipe.PrepMinutes,
+		Calories:    recipe.Calories,
+		Cost:        recipe.Cost,
+		Ingredients: recipe.Ingredients,
+		Tags:        recipe.Tags,
+	}
+	if len(recipe.StructuredIngredients) > 0 {
+		card.Ingredients = nil
+		for _, ing := range recipe.StructuredIngredients {
+			card.Ingredients = append(card.Ingredients, ing.Raw)
+		}
+	}
+
+	if recipe.NutritionFacts != nil {
+		card.Servings = recipe.NutritionFacts.Servings
+		facts := recipe.NutritionFacts.PerServingFacts()
+		for _, n := range []nutrient{
+			{"Protein", "proteinContent", facts.ProteinG, "g"},
+			{"Carbohydrates", "carbohydrateContent", facts.CarbsG, "g"},
+			{"Fat", "fatContent", facts.FatG, "g"},
+			{"Fiber", "fiberContent", facts.FiberG, "g"},
+			{"Sodium", "sodiumContent", facts.SodiumMg, "mg"},
+			{"Sugar", "sugarContent", facts.SugarG, "g"},
+		} {
+			if n.Amount > 0 {
+				card.Nutrition = append(card.Nutrition, n)
+			}
+		}
+	}
+	return card
+}
+
+// writeJSONLD renders a single recipe as a schema.org Recipe, and several
+// recipes or a plan cookbook as an ItemList of them
+func writeJSONLD(buf *bytes.Buffer, book cookbook, asList bool) error {
+	var doc map[string]interface{}
+	if len(book.Recipes) == 1 && !asList {
+		doc = recipeJSONLD(book.Recipes[0])
+	} else {
+		items := make([]interface{}, len(book.Recipes))
+		for i, card := range book.Recipes {
+			recipe := recipeJSONLD(card)
+			delete(recipe, "@context")
+			items[i] = map[string]interface{}{"@type": "ListItem", "position": i + 1, "item": recipe}
+		}
+		doc = map[string]interface{}{
+			"@context":        "https://schema.org",
+			"@type":           "ItemList",
+			"name":            book.Title,
+			"numberOfItems":   len(items),
+			"itemListElement": items,
+		}
+	}
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+func recipeJSONLD(card recipeCard) map[string]interface{} {
+	recipe := map[string]interface{}{
+		"@context":         "https://schema.org",
+		"@type":            "Recipe",
+		"identifier":       card.ID,
+		"name":             card.Name,
+		"recipeIngredient": card.Ingredients,
+	}
+	if card.Cuisine != "" {
+		recipe["recipeCuisine"] = card.Cuisine
+	}
+	if card.PrepMinutes > 0 {
+		recipe["totalTime"] = fmt.Sprintf("PT%dM", card.PrepMinutes)
+	}
+	if len(card.Tags) > 0 {
+		recipe["keywords"] = strings.Join(card.Tags, ", ")
+	}
+	if card.Servings > 0 {
+		recipe["recipeYield"] = fmt.Sprintf("%d servings", card.Servings)
+	}
+	if card.Cost > 0 {
+		recipe["estimatedCost"] = map[string]interface{}{"@type": "MonetaryAmount", "value": card.Cost}
+	}
+
+	if card.Calories > 0 || len(card.Nutrition) > 0 {
+		nutrition := map[string]interface{}{"@type": "NutritionInformation"}
+		if card.Calories > 0 {
+			nutrition["calories"] = fmt.Sprintf("%d calories", card.Calories)
+		}
+		for _, n := range card.Nutrition {
+			nutrition[n.Property] = fmt.Sprintf("%g %s", roundTenth(n.Amount), n.Unit)
+		}
+		recipe["nutrition"] = nutrition
+	}
+	return recipe
+}
+
+func roundTenth(v float64) float64 {
+	return float64(int64(v*10+0.5)) / 10
+}
+
+// slug turns a title into a file name
+func slug(title string) string {
+	s := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if s == "" {
+		return "recipe"
+	}
+	return s
+}
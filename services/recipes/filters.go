@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"spiceroute/pkg/allergens"
+	pb "spiceroute/proto"
+
+	"gorm.io/gorm"
+)
+
+// spicyTag marks recipes that satisfy RecipeQuery.spicy
+const spicyTag = "spicy"
+
+// validateRecipeQuery rejects filter combinations that can never match
+func validateRecipeQuery(q *pb.RecipeQuery) error {
+	if q.MaxPrepMinutes < 0 || q.MinCalories < 0 || q.MaxCalories < 0 || q.MaxCost < 0 {
+		return fmt.Errorf("numeric filters must not be negative")
+	}
+	if q.MaxCalories > 0 && q.MinCalories > q.MaxCalories {
+		return fmt.Errorf("min_calories %d exceeds max_calories %d", q.MinCalories, q.MaxCalories)
+	}
+	return nil
+}
+
+// applyRecipeFilters narrows a recipe query by the attribute filters in q.
+// Zero values leave the corresponding filter off.
+func applyRecipeFilters(query *gorm.DB, q *pb.RecipeQuery) *gorm.DB {
+	if len(q.Cuisines) > 0 {
+		query = query.Where("lower(cuisine) IN ?", lowerAll(q.Cuisines))
+	}
+
+	if q.MaxPrepMinutes > 0 {
+		query = query.Where("prep_minutes <= ?", q.MaxPrepMinutes)
+	}
+	if q.MinCalories > 0 {
+		query = query.Where("calories >= ?", q.MinCalories)
+	}
+	if q.MaxCalories > 0 {
+		query = query.Where("calories <= ?", q.MaxCalories)
+	}
+	if q.MaxCost > 0 {
+		query = query.Where("cost <= ?", q.MaxCost)
+	}
+
+	// Tags are matched with array containment, so a recipe must carry all of them
+	tags := q.Tags
+	if q.Spicy {
+		tags = append(append([]string{}, tags...), spicyTag)
+	}
+	if len(tags) > 0 {
+		query = query.Where("tags @> ARRAY[?]::text[]", tags)
+	}
+
+	// Ingredients are free-text lines ("2 cups basmati rice"), so exclusions
+	// match case-insensitively anywhere within each line.
+	if len(q.ExcludeIngredients) > 0 {
+		patterns := make([]string, 0, len(q.ExcludeIngredients))
+		for _, ingredient := range q.ExcludeIngredients {
+			if ingredient = strings.TrimSpace(ingredient); ingredient != "" {
+				patterns = append(patterns, "%"+ingredient+"%")
+			}
+		}
+		if len(patterns) > 0 {
+			query = query.Where("NOT EXISTS (SELECT 1 FROM unnest(ingredients) AS ing WHERE ing ILIKE ANY (ARRAY[?]::text[]))", patterns)
+		}
+	}
+
+	return query
+}
+
+// excludeAllergens drops recipes with any ingredient line carrying one of the
+// given allergies, using the same synonym table as CheckAllergens.
+func excludeAllergens(query *gorm.DB, allergies []string) *gorm.DB {
+	patterns := allergens.SQLPatterns(allergies)
+	if len(patterns) == 0 {
+		return query
+	}
+	return query.Where("NOT EXISTS (SELECT 1 FROM unnest(ingredients) AS ing WHERE ing ~* ANY (ARRAY[?]::text[]))", patterns)
+}
+
+// lowerAll lowercases values for matching against a lower(column), so
+// cuisine filters ignore case wherever recipes are listed or searched
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, v := range values {
+		lowered[i] = strings.ToLower(v)
+	}
+	return lowered
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"spiceroute/pkg/grpcerr"
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// importBatchSize is how many rows an import writes per transaction
+const importBatchSize = 100
+
+// normalizedKey is the SQL form of recipeKey, used to find stored duplicates
+const normalizedKey = `regexp_replace(lower(trim(name)), '\s+', ' ', 'g') || '|' || regexp_replace(lower(trim(coalesce(cuisine, ''))), '\s+', ' ', 'g')`
+
+// importRow is a received row waiting for its batch to be written
+type importRow struct {
+	*pb.RecipeImportRow
+	key string
+}
+
+// ImportRecipes stores a stream of recipes in batched transactions. Rows
+// that repeat a stored recipe, or an earlier row, by normalized name and
+// cuisine are skipped; invalid rows are reported and the rest still land.
+func (s *server) ImportRecipes(stream pb.RecipeService_ImportRecipesServer) error {
+	ctx := stream.Context()
+	result := &pb.RecipeImportResult{}
+	seen := make(map[string]bool)
+	var batch []importRow
+
+	for {
+		row, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		result.Received++
+
+		if err := validateImport(row.Recipe); err != nil {
+			reportImportError(result, row, err)
+			continue
+		}
+		key := recipeKey(row.Recipe.Name, row.Recipe.Cuisine)
+		if seen[key] {
+			result.Duplicates++
+			continue
+		}
+		seen[key] = true
+
+		batch = append(batch, importRow{RecipeImportRow: row, key: key})
+		if len(batch) == importBatchSize {
+			if err := s.importBatch(s.db.WithContext(ctx), batch, result); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := s.importBatch(s.db.WithContext(ctx), batch, result); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Imported %d of %d recipes (%d duplicates, %d failed)",
+		result.Created, result.Received, result.Duplicates, result.Failed)
+	return stream.SendAndClose(result)
+}
+
+// importBatch writes one batch in a single transaction, skipping rows that
+// duplicate stored recipes. Each row gets a savepoint so one the database
+// refuses does not undo the others.
+func (s *server) importBatch(db *gorm.DB, batch []importRow, result *pb.RecipeImportResult) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		keys := make([]string, len(batch))
+		for i, row := range batch {
+			keys[i] = row.key
+		}
+		var stored []string
+		err := tx.Model(&models.Recipe{}).
+			Where(normalizedKey+" IN ?", keys).
+			Pluck(normalizedKey, &stored).Error
+		if err != nil {
+			return err
+		}
+		exists := make(map[string]bool, len(stored))
+		for _, key := range stored {
+			exists[key] = true
+		}
+
+		for _, row := range batch {
+			if exists[row.key] {
+				result.Duplicates++
+				continue
+			}
+			recipe := recipeFromProto(row.Recipe)
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				return tx.Create(&recipe).Error
+			})
+			if err != nil {
+				// The row's error carries a fixed message; the database's
+				// own names constraints and columns, so it is only logged
+				log.Printf("Failed to import row %d of %s: %v", row.Row, row.Source, err)
+				reportImportError(result, row.RecipeImportRow, grpcerr.FromDB(err))
+				continue
+			}
+			result.Created++
+		}
+		return nil
+	})
+}
+
+// validateImport checks the fields an imported recipe must get right
+func validateImport(r *pb.Recipe) error {
+	switch {
+	case r == nil:
+		return fmt.Errorf("row has no recipe")
+	case strings.TrimSpace(r.Name) == "":
+		return fmt.Errorf("name is required")
+	case r.PrepMinutes < 0, r.Calories < 0, r.Cost < 0, r.ShelfLifeDays < 0:
+		return fmt.Errorf("prep_minutes, calories, cost and shelf_life_days must not be negative")
+	}
+	return nil
+}
+
+func reportImportError(result *pb.RecipeImportResult, row *pb.RecipeImportRow, err error) {
+	result.Failed++
+	message := err.Error()
+	if s, ok := status.FromError(err); ok {
+		message = s.Message()
+	}
+	result.Errors = append(result.Errors, &pb.RecipeImportError{
+		Source: row.Source,
+		Row:    row.Row,
+		Name:   row.GetRecipe().GetName(),
+		Error:  message,
+	})
+}
+
+// recipeKey identifies a recipe for deduplication: its name and cuisine,
+// lowercased with runs of whitespace collapsed
+func recipeKey(name, cuisine string) string {
+	normalize := func(s string) string { return strings.Join(strings.Fields(strings.ToLower(s)), " ") }
+	return normalize(name) + "|" + normalize(cuisine)
+}
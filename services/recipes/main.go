@@ -2,34 +2,34 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
-	"net"
+	"time"
 
+	"spiceroute/pkg/config"
 	"spiceroute/pkg/database"
+	"spiceroute/pkg/grpcserver"
+	"spiceroute/pkg/ingredients"
 	"spiceroute/pkg/models"
+	"spiceroute/pkg/moods"
+	"spiceroute/pkg/nutrition"
 	pb "spiceroute/proto"
 
-	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"gorm.io/gorm"
 )
 
 type server struct {
 	db *gorm.DB
+	// reader serves read-heavy queries that tolerate replication lag
+	reader *gorm.DB
 	pb.UnimplementedRecipeServiceServer
 }
 
 func (s *server) CreateRecipe(ctx context.Context, r *pb.Recipe) (*pb.RecipeID, error) {
-	recipe := models.Recipe{
-		Name:          r.Name,
-		Cuisine:       r.Cuisine,
-		PrepMinutes:   r.PrepMinutes,
-		Calories:      r.Calories,
-		Ingredients:   r.Ingredients,
-		Cost:          r.Cost,
-		ShelfLifeDays: r.ShelfLifeDays,
-		Tags:          r.Tags,
-		Nutrition:     r.Nutrition,
-	}
+	recipe := recipeFromProto(r)
 
 	result := s.db.WithContext(ctx).Create(&recipe)
 	if result.Error != nil {
@@ -39,66 +39,359 @@ func (s *server) CreateRecipe(ctx context.Context, r *pb.Recipe) (*pb.RecipeID,
 	return &pb.RecipeID{Id: recipe.ID}, nil
 }
 
+func (s *server) GetRecipe(ctx context.Context, id *pb.RecipeID) (*pb.Recipe, error) {
+	if id.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipe id is required")
+	}
+
+	var recipe models.Recipe
+	result := preloadIngredients(s.db.WithContext(ctx)).Where("id = ?", id.Id).First(&recipe)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "recipe %s not found", id.Id)
+		}
+		return nil, result.Error
+	}
+
+	return recipeToProto(recipe), nil
+}
+
+func (s *server) UpdateRecipe(ctx context.Context, r *pb.Recipe) (*pb.Recipe, error) {
+	if r.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipe id is required")
+	}
+
+	var recipe models.Recipe
+	result := s.db.WithContext(ctx).Where("id = ?", r.Id).First(&recipe)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "recipe %s not found", r.Id)
+		}
+		return nil, result.Error
+	}
+
+	lines, structured := ingredientsFromProto(r)
+
+	recipe.Name = r.Name
+	recipe.Cuisine = r.Cuisine
+	recipe.PrepMinutes = r.PrepMinutes
+	recipe.Calories = r.Calories
+	recipe.Ingredients = lines
+	recipe.Cost = r.Cost
+	recipe.ShelfLifeDays = r.ShelfLifeDays
+	recipe.Tags = r.Tags
+	recipe.Nutrition = r.Nutrition
+	recipe.NutritionFacts = nutritionFromProto(r.NutritionFacts, r.Nutrition)
+
+	// Replace the structured ingredient rows wholesale
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("StructuredIngredients").Save(&recipe).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("recipe_id = ?", recipe.ID).Delete(&models.Ingredient{}).Error; err != nil {
+			return err
+		}
+		for i := range structured {
+			structured[i].RecipeID = recipe.ID
+		}
+		if len(structured) > 0 {
+			return tx.Create(&structured).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	recipe.StructuredIngredients = structured
+
+	return recipeToProto(recipe), nil
+}
+
+// DeleteRecipe soft-deletes a recipe; it can be brought back with RestoreRecipe.
+func (s *server) DeleteRecipe(ctx context.Context, id *pb.RecipeID) (*emptypb.Empty, error) {
+	if id.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipe id is required")
+	}
+
+	result := s.db.WithContext(ctx).Where("id = ?", id.Id).Delete(&models.Recipe{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Errorf(codes.NotFound, "recipe %s not found", id.Id)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RestoreRecipe clears the soft-delete marker on a previously deleted recipe.
+func (s *server) RestoreRecipe(ctx context.Context, id *pb.RecipeID) (*pb.Recipe, error) {
+	if id.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipe id is required")
+	}
+
+	result := s.db.WithContext(ctx).Unscoped().Model(&models.Recipe{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id.Id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Errorf(codes.NotFound, "deleted recipe %s not found", id.Id)
+	}
+
+	return s.GetRecipe(ctx, id)
+}
+
 func (s *server) ListRecipes(ctx context.Context, q *pb.RecipeQuery) (*pb.RecipeList, error) {
 	var recipes []models.Recipe
 
-	query := s.db.WithContext(ctx).Model(&models.Recipe{})
+	if err := validateRecipeQuery(q); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Apply filters if provided. Listing is read-only, so it can run on a
+	// replica.
+	query := applyRecipeFilters(preloadIngredients(s.reader.WithContext(ctx)).Model(&models.Recipe{}), q)
 
-	// Apply filters if provided
-	if len(q.Cuisines) > 0 {
-		query = query.Where("cuisine IN ?", q.Cuisines)
+	if q.ExcludeAllergensForUser != "" {
+		allergies, err := s.userAllergies(ctx, q.ExcludeAllergensForUser)
+		if err != nil {
+			return nil, err
+		}
+		query = excludeAllergens(query, allergies)
 	}
 
-	// Note: Spicy filter would need to be implemented based on recipe tags or a separate field
-	// For now, we'll just limit the results
-	query = query.Limit(100)
+	// Explicit cuisines win; otherwise use what the user wants this week
+	if q.CuisinesForUser != "" && len(q.Cuisines) == 0 {
+		cuisines, _, _, err := moods.Effective(ctx, s.reader, q.CuisinesForUser, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if len(cuisines) > 0 {
+			query = query.Where("lower(cuisine) IN ?", lowerAll(cuisines))
+		}
+	}
+
+	// Count matches before the cursor narrows the result set
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	orderBy := q.OrderBy
+	if orderBy == "" {
+		orderBy = "name"
+	}
+	if !sortableColumns[orderBy] {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot order by %q", q.OrderBy)
+	}
+
+	var cursor *pageCursor
+	if q.PageToken != "" {
+		c, err := decodePageCursor(q.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if c.OrderBy != orderBy || c.Descending != q.Descending {
+			return nil, status.Error(codes.InvalidArgument, "page token does not match the requested ordering")
+		}
+		cursor = &c
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	pageSize := normalizePageSize(q.PageSize)
+	query = applyCursor(query, orderBy, q.Descending, cursor).Limit(pageSize + 1)
 
 	result := query.Find(&recipes)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
+	var nextPageToken string
+	if len(recipes) > pageSize {
+		recipes = recipes[:pageSize]
+		nextPageToken = newPageCursor(recipes[pageSize-1], orderBy, q.Descending).encode()
+	}
+
 	// Convert to protobuf
 	var pbRecipes []*pb.Recipe
 	for _, recipe := range recipes {
-		pbRecipes = append(pbRecipes, &pb.Recipe{
-			Id:            recipe.ID,
-			Name:          recipe.Name,
-			Cuisine:       recipe.Cuisine,
-			PrepMinutes:   recipe.PrepMinutes,
-			Calories:      recipe.Calories,
-			Ingredients:   recipe.Ingredients,
-			Cost:          recipe.Cost,
-			ShelfLifeDays: recipe.ShelfLifeDays,
-			Tags:          recipe.Tags,
-			Nutrition:     recipe.Nutrition,
+		pbRecipes = append(pbRecipes, recipeToProto(recipe))
+	}
+
+	return &pb.RecipeList{
+		Recipes:       pbRecipes,
+		NextPageToken: nextPageToken,
+		TotalCount:    total,
+	}, nil
+}
+
+// recipeFromProto converts a new recipe to its model, deriving the
+// structured ingredients and nutrition facts the caller left out
+func recipeFromProto(r *pb.Recipe) models.Recipe {
+	lines, structured := ingredientsFromProto(r)
+
+	return models.Recipe{
+		Name:                  r.Name,
+		Cuisine:               r.Cuisine,
+		PrepMinutes:           r.PrepMinutes,
+		Calories:              r.Calories,
+		Ingredients:           lines,
+		Cost:                  r.Cost,
+		ShelfLifeDays:         r.ShelfLifeDays,
+		Tags:                  r.Tags,
+		Nutrition:             r.Nutrition,
+		NutritionFacts:        nutritionFromProto(r.NutritionFacts, r.Nutrition),
+		StructuredIngredients: structured,
+	}
+}
+
+// recipeToProto converts a recipe model to its protobuf representation
+func recipeToProto(recipe models.Recipe) *pb.Recipe {
+	return &pb.Recipe{
+		Id:                    recipe.ID,
+		Name:                  recipe.Name,
+		Cuisine:               recipe.Cuisine,
+		PrepMinutes:           recipe.PrepMinutes,
+		Calories:              recipe.Calories,
+		Ingredients:           recipe.Ingredients,
+		Cost:                  recipe.Cost,
+		ShelfLifeDays:         recipe.ShelfLifeDays,
+		Tags:                  recipe.Tags,
+		Nutrition:             recipe.Nutrition,
+		NutritionFacts:        nutritionToProto(recipe.NutritionFacts),
+		StructuredIngredients: ingredientsToProto(recipe.StructuredIngredients),
+	}
+}
+
+// preloadIngredients loads structured ingredients in recipe order
+func preloadIngredients(db *gorm.DB) *gorm.DB {
+	return db.Preload("StructuredIngredients", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	})
+}
+
+// ingredientsFromProto returns both the free-text lines and the structured
+// ingredients for a recipe. Whichever form the caller sent is authoritative;
+// the other is derived from it.
+func ingredientsFromProto(r *pb.Recipe) ([]string, []models.Ingredient) {
+	var structured []models.Ingredient
+	if len(r.StructuredIngredients) == 0 {
+		for i, line := range r.Ingredients {
+			ing := ingredients.Parse(line)
+			ing.Position = int32(i)
+			structured = append(structured, ing)
+		}
+		return r.Ingredients, structured
+	}
+
+	lines := make([]string, 0, len(r.StructuredIngredients))
+	for i, in := range r.StructuredIngredients {
+		ing := models.Ingredient{
+			Position:    int32(i),
+			Name:        in.Name,
+			Quantity:    in.Quantity,
+			Unit:        ingredients.NormalizeUnit(in.Unit),
+			Note:        in.Note,
+			CanonicalID: in.CanonicalId,
+			Raw:         in.Raw,
+		}
+		if ing.CanonicalID == "" {
+			ing.CanonicalID = ingredients.CanonicalID(ing.Name)
+		}
+		if ing.Raw == "" {
+			ing.Raw = ingredients.Format(ing)
+		}
+		structured = append(structured, ing)
+		lines = append(lines, ing.Raw)
+	}
+	return lines, structured
+}
+
+// ingredientsToProto converts structured ingredient models to protobuf
+func ingredientsToProto(items []models.Ingredient) []*pb.Ingredient {
+	var out []*pb.Ingredient
+	for _, ing := range items {
+		out = append(out, &pb.Ingredient{
+			Name:        ing.Name,
+			Quantity:    ing.Quantity,
+			Unit:        ing.Unit,
+			Note:        ing.Note,
+			CanonicalId: ing.CanonicalID,
+			Raw:         ing.Raw,
 		})
 	}
+	return out
+}
 
-	return &pb.RecipeList{Recipes: pbRecipes}, nil
+// nutritionFromProto converts structured nutrition facts to the model,
+// falling back to parsing the free-text label when none were sent.
+func nutritionFromProto(n *pb.NutritionFacts, label string) *models.NutritionFacts {
+	if n == nil {
+		facts, ok := nutrition.Parse(label)
+		if !ok {
+			return nil
+		}
+		return &facts
+	}
+	return &models.NutritionFacts{
+		ProteinG:   n.ProteinG,
+		CarbsG:     n.CarbsG,
+		FatG:       n.FatG,
+		FiberG:     n.FiberG,
+		SodiumMg:   n.SodiumMg,
+		SugarG:     n.SugarG,
+		Servings:   n.Servings,
+		PerServing: n.PerServing,
+	}
+}
+
+// nutritionToProto converts model nutrition facts to protobuf
+func nutritionToProto(n *models.NutritionFacts) *pb.NutritionFacts {
+	if n == nil {
+		return nil
+	}
+	return &pb.NutritionFacts{
+		ProteinG:   n.ProteinG,
+		CarbsG:     n.CarbsG,
+		FatG:       n.FatG,
+		FiberG:     n.FiberG,
+		SodiumMg:   n.SodiumMg,
+		SugarG:     n.SugarG,
+		Servings:   n.Servings,
+		PerServing: n.PerServing,
+	}
 }
 
 func main() {
+	cfg, err := config.Load("recipes")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
 	// Initialize database connection
-	db, err := database.NewConnection()
+	db, err := database.NewConnection(cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	// Run migrations
-	if err := database.AutoMigrate(db); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+	// Refuse to start against a schema older than this build
+	if err := database.VerifySchema(db); err != nil {
+		log.Fatal("Database schema check failed:", err)
 	}
 
-	// Start gRPC server
-	lis, err := net.Listen("tcp", ":50053")
+	reader, err := database.NewReadConnection(cfg.Database, db)
 	if err != nil {
-		log.Fatal("Failed to listen:", err)
+		log.Fatal("Failed to connect to read replica:", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterRecipeServiceServer(grpcServer, &server{db: db})
+	// Start gRPC server
+	grpcServer := grpcserver.NewServer()
+	pb.RegisterRecipeServiceServer(grpcServer, &server{db: db, reader: reader})
 
-	log.Println("Recipe service starting on :50053")
-	log.Fatal(grpcServer.Serve(lis))
+	log.Printf("Recipe service starting on %s", cfg.ListenAddr())
+	if err := grpcserver.Serve(grpcServer, cfg, db); err != nil {
+		log.Fatal("Failed to serve:", err)
+	}
 }
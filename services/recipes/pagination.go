@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"spiceroute/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// sortableColumns lists the columns ListRecipes can order by
+var sortableColumns = map[string]bool{
+	"name":         true,
+	"cost":         true,
+	"prep_minutes": true,
+	"calories":     true,
+	"created_at":   true,
+}
+
+// pageCursor is the decoded form of the opaque page token. It records the
+// sort key of the last recipe on the previous page so the next page can
+// resume with a keyset condition instead of an offset.
+type pageCursor struct {
+	OrderBy    string    `json:"o"`
+	Descending bool      `json:"d,omitempty"`
+	ID         string    `json:"id"`
+	Text       string    `json:"s,omitempty"`
+	Number     float64   `json:"n,omitempty"`
+	Time       time.Time `json:"t,omitempty"`
+}
+
+// normalizePageSize clamps the requested page size to sane bounds
+func normalizePageSize(size int32) int {
+	if size <= 0 {
+		return defaultPageSize
+	}
+	if size > maxPageSize {
+		return maxPageSize
+	}
+	return int(size)
+}
+
+// newPageCursor captures the sort key of a recipe for the next page token
+func newPageCursor(recipe models.Recipe, orderBy string, desc bool) pageCursor {
+	c := pageCursor{OrderBy: orderBy, Descending: desc, ID: recipe.ID}
+	switch orderBy {
+	case "name":
+		c.Text = recipe.Name
+	case "cost":
+		c.Number = recipe.Cost
+	case "prep_minutes":
+		c.Number = float64(recipe.PrepMinutes)
+	case "calories":
+		c.Number = float64(recipe.Calories)
+	case "created_at":
+		c.Time = recipe.CreatedAt
+	}
+	return c
+}
+
+// value returns the cursor's sort key typed to match its column
+func (c pageCursor) value() interface{} {
+	switch c.OrderBy {
+	case "name":
+		return c.Text
+	case "cost":
+		return c.Number
+	case "prep_minutes", "calories":
+		return int32(c.Number)
+	default:
+		return c.Time
+	}
+}
+
+// encode serializes the cursor into an opaque, URL-safe token
+func (c pageCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageCursor parses a page token produced by pageCursor.encode
+func decodePageCursor(token string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("malformed page token")
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("malformed page token")
+	}
+	if !sortableColumns[c.OrderBy] || c.ID == "" {
+		return c, fmt.Errorf("malformed page token")
+	}
+	return c, nil
+}
+
+// applyCursor orders the query by the sort column (with id as a tie-breaker)
+// and, when a cursor is given, skips everything up to and including it.
+func applyCursor(query *gorm.DB, orderBy string, desc bool, cursor *pageCursor) *gorm.DB {
+	dir, cmp := "ASC", ">"
+	if desc {
+		dir, cmp = "DESC", "<"
+	}
+
+	if cursor != nil {
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", orderBy, cmp), cursor.value(), cursor.ID)
+	}
+
+	return query.Order(fmt.Sprintf("%s %s, id %s", orderBy, dir, dir))
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+	// nameSimilarity is the word similarity at which a misspelt query still
+	// matches a recipe name; pg_trgm's default of 0.6 misses most typos
+	nameSimilarity = 0.4
+	// Highlight markers ts_headline wraps matches in. Control characters
+	// cannot appear in recipe text, so the snippet can be escaped safely
+	// before they become <mark> tags.
+	highlightStart = "\x01"
+	highlightStop  = "\x02"
+)
+
+// searchSQL ranks recipes by their weighted search vector (name, then
+// cuisine and tags, then ingredients) plus how closely the name resembles
+// the query, so typos in a name still find it
+const searchSQL = `
+SELECT id,
+	ts_rank_cd(search_vector, to_tsquery('english', @prefix), 32)
+		+ 0.5 * word_similarity(lower(@query), lower(name)) AS score,
+	ts_headline('english',
+		name || '. ' || recipe_search_text(tags) || '. ' || coalesce(array_to_string(ingredients, ', '), ''),
+		to_tsquery('english', @prefix),
+		'StartSel="' || @start || '", StopSel="' || @stop || '", MaxFragments=2, MinWords=5, MaxWords=20') AS snippet
+FROM recipes
+WHERE deleted_at IS NULL
+	AND (search_vector @@ to_tsquery('english', @prefix) OR lower(@query) <% lower(name))`
+
+// searchHit is one ranked row of searchSQL
+type searchHit struct {
+	ID      string
+	Score   float64
+	Snippet string
+}
+
+// SearchRecipes finds recipes matching free text, best matches first, with
+// a highlighted snippet of where each matched
+func (s *server) SearchRecipes(ctx context.Context, req *pb.RecipeSearchRequest) (*pb.RecipeSearchResponse, error) {
+	prefix := prefixQuery(req.Query)
+	if prefix == "" {
+		return nil, status.Error(codes.InvalidArgument, "query must contain at least one word")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+
+	sql := searchSQL
+	args := map[string]interface{}{
+		"prefix": prefix,
+		"query":  req.Query,
+		"start":  highlightStart,
+		"stop":   highlightStop,
+		"limit":  limit,
+		"offset": req.Offset,
+	}
+	if len(req.Cuisines) > 0 {
+		sql += "\n\tAND lower(cuisine) IN @cuisines"
+		args["cuisines"] = lowerAll(req.Cuisines)
+	}
+	sql += "\nORDER BY score DESC, name\nLIMIT @limit OFFSET @offset"
+
+	var hits []searchHit
+	err := s.reader.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SET LOCAL cannot take a bind parameter
+		threshold := fmt.Sprintf("SET LOCAL pg_trgm.word_similarity_threshold = %g", nameSimilarity)
+		if err := tx.Exec(threshold).Error; err != nil {
+			return err
+		}
+		return tx.Raw(sql, args).Scan(&hits).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return &pb.RecipeSearchResponse{}, nil
+	}
+
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	var recipes []models.Recipe
+	if err := preloadIngredients(s.reader.WithContext(ctx)).Where("id IN ?", ids).Find(&recipes).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.Recipe, len(recipes))
+	for _, recipe := range recipes {
+		byID[recipe.ID] = recipe
+	}
+
+	resp := &pb.RecipeSearchResponse{}
+	for _, hit := range hits {
+		recipe, ok := byID[hit.ID]
+		if !ok {
+			continue // deleted between the two queries
+		}
+		resp.Hits = append(resp.Hits, &pb.RecipeSearchHit{
+			Recipe:  recipeToProto(recipe),
+			Score:   hit.Score,
+			Snippet: highlight(hit.Snippet),
+		})
+	}
+	return resp, nil
+}
+
+// prefixQuery turns free text into a tsquery matching every word as a
+// prefix, e.g. "chick curr" becomes "chick:* & curr:*". Anything but
+// letters and digits is dropped so user input cannot break the syntax.
+func prefixQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// highlight escapes a snippet and turns the highlight markers into <mark>
+func highlight(snippet string) string {
+	snippet = html.EscapeString(snippet)
+	snippet = strings.ReplaceAll(snippet, highlightStart, "<mark>")
+	return strings.ReplaceAll(snippet, highlightStop, "</mark>")
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"math"
+	"sort"
+
+	"spiceroute/pkg/ingredients"
+	"spiceroute/pkg/models"
+)
+
+// aggregate merges the ingredients of every recipe in a plan into one line
+// per canonical ingredient and unit dimension, scaled to the servings the
+// plan schedules. Ingredient amounts are per recipe; a recipe's yield comes
+// from its nutrition facts, and recipes without one are assumed to make a
+// single serving.
+func aggregate(recipes []models.Recipe, servings map[string]int32) []models.ShoppingItem {
+	type key struct{ canonical, unit string }
+	totals := make(map[key]*models.ShoppingItem)
+	var order []key
+
+	for _, recipe := range recipes {
+		count := servings[recipe.ID]
+		if count == 0 {
+			continue
+		}
+		yield := int32(1)
+		if recipe.NutritionFacts != nil && recipe.NutritionFacts.Servings > 0 {
+			yield = recipe.NutritionFacts.Servings
+		}
+		factor := float64(count) / float64(yield)
+
+		for _, ing := range recipe.StructuredIngredients {
+			canonical := ing.CanonicalID
+			if canonical == "" {
+				canonical = ingredients.CanonicalID(ing.Name)
+			}
+			quantity, unit := ingredients.ToBase(ing.Quantity*factor, ing.Unit, canonical)
+
+			k := key{canonical, unit}
+			item, ok := totals[k]
+			if !ok {
+				item = &models.ShoppingItem{
+					CanonicalID: canonical,
+					Name:        ing.Name,
+					Unit:        unit,
+					Category:    ingredients.Aisle(canonical),
+				}
+				totals[k] = item
+				order = append(order, k)
+			}
+			item.Quantity += quantity
+		}
+	}
+
+	// Liquids stay in millilitres unless a recipe also weighs the same
+	// ingredient, in which case the volume joins the weight
+	for _, k := range order {
+		mass, ok := totals[key{k.canonical, "g"}]
+		if k.unit != "ml" || !ok {
+			continue
+		}
+		grams, err := ingredients.Convert(totals[k].Quantity, "ml", "g", k.canonical)
+		if err != nil {
+			continue
+		}
+		mass.Quantity += grams
+		delete(totals, k)
+	}
+
+	items := make([]models.ShoppingItem, 0, len(order))
+	for _, k := range order {
+		if item, ok := totals[k]; ok {
+			items = append(items, *item)
+		}
+	}
+	return items
+}
+
+// subtractPantry reduces items, still in base units, by the matching stock
+// in the pantry. Items the pantry covers completely are dropped.
+func subtractPantry(items []models.ShoppingItem, pantry []models.PantryItem) []models.ShoppingItem {
+	type key struct{ canonical, unit string }
+	listed := make(map[key]bool, len(items))
+	for _, item := range items {
+		listed[key{item.CanonicalID, item.Unit}] = true
+	}
+
+	stock := make(map[key]float64)
+	for _, p := range pantry {
+		quantity, unit := ingredients.ToBase(p.Quantity, p.Unit, p.CanonicalID)
+		// A liquid can be listed by weight and stocked by volume, or the
+		// other way round; count the stock in the list's unit
+		var other string
+		switch unit {
+		case "g":
+			other = "ml"
+		case "ml":
+			other = "g"
+		}
+		if other != "" && !listed[key{p.CanonicalID, unit}] && listed[key{p.CanonicalID, other}] {
+			if converted, err := ingredients.Convert(quantity, unit, other, p.CanonicalID); err == nil {
+				quantity, unit = converted, other
+			}
+		}
+		stock[key{p.CanonicalID, unit}] += quantity
+	}
+
+	kept := items[:0]
+	for _, item := range items {
+		k := key{item.CanonicalID, item.Unit}
+		if available := stock[k]; available > 0 && item.Quantity > 0 {
+			used := math.Min(available, item.Quantity)
+			stock[k] -= used
+			item.Quantity -= used
+			item.FromPantry = used
+			if item.Quantity <= 0 {
+				continue
+			}
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+// finalize rescales items for display, rounding purchases up, and orders
+// them by aisle
+func finalize(items []models.ShoppingItem) []models.ShoppingItem {
+	for i := range items {
+		quantity, unit := ingredients.Humanize(items[i].Quantity, items[i].Unit)
+		if unit != items[i].Unit {
+			// Keep the pantry share in the same unit as the purchase
+			items[i].FromPantry = items[i].FromPantry * quantity / items[i].Quantity
+		}
+		items[i].Quantity, items[i].Unit = quantity, unit
+		items[i].Quantity = math.Ceil(items[i].Quantity*100) / 100
+		items[i].FromPantry = math.Round(items[i].FromPantry*100) / 100
+	}
+	sortItems(items)
+	return items
+}
+
+// sortItems orders items by aisle, then by name
+func sortItems(items []models.ShoppingItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ri, rj := ingredients.AisleRank(items[i].Category), ingredients.AisleRank(items[j].Category)
+		if ri != rj {
+			return ri < rj
+		}
+		return items[i].Name < items[j].Name
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"spiceroute/pkg/models"
+)
+
+func TestAggregateKeepsLiquidsByVolume(t *testing.T) {
+	recipes := []models.Recipe{
+		{ID: "porridge", StructuredIngredients: []models.Ingredient{
+			{Name: "milk", CanonicalID: "milk", Quantity: 2, Unit: "cup"},
+			{Name: "flour", CanonicalID: "flour", Quantity: 1, Unit: "cup"},
+		}},
+		{ID: "soup", StructuredIngredients: []models.Ingredient{
+			{Name: "chicken stock", CanonicalID: "chicken-stock", Quantity: 1, Unit: "l"},
+			{Name: "flour", CanonicalID: "flour", Quantity: 50, Unit: "g"},
+		}},
+	}
+	items := aggregate(recipes, map[string]int32{"porridge": 1, "soup": 1})
+
+	want := map[string]struct {
+		quantity float64
+		unit     string
+	}{
+		"milk":          {473.176, "ml"},
+		"chicken-stock": {1000, "ml"},
+		"flour":         {236.588*0.53 + 50, "g"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d items %+v, want %d", len(items), items, len(want))
+	}
+	for _, item := range items {
+		w := want[item.CanonicalID]
+		if item.Unit != w.unit || math.Abs(item.Quantity-w.quantity) > 1e-6 {
+			t.Errorf("%s: got %v %s, want %v %s", item.CanonicalID, item.Quantity, item.Unit, w.quantity, w.unit)
+		}
+	}
+}
+
+func TestAggregateMergesLiquidIntoWeight(t *testing.T) {
+	recipes := []models.Recipe{
+		{ID: "pancakes", StructuredIngredients: []models.Ingredient{
+			{Name: "milk", CanonicalID: "milk", Quantity: 100, Unit: "ml"},
+		}},
+		{ID: "custard", StructuredIngredients: []models.Ingredient{
+			{Name: "milk", CanonicalID: "milk", Quantity: 200, Unit: "g"},
+		}},
+	}
+	items := aggregate(recipes, map[string]int32{"pancakes": 1, "custard": 1})
+	if len(items) != 1 || items[0].Unit != "g" || math.Abs(items[0].Quantity-303) > 1e-6 {
+		t.Fatalf("got %+v, want one line of 303 g milk", items)
+	}
+
+	// Milk stocked by volume still covers milk listed by weight
+	left := subtractPantry(items, []models.PantryItem{{CanonicalID: "milk", Quantity: 1, Unit: "l"}})
+	if len(left) != 0 {
+		t.Errorf("got %+v, want the pantry to cover the milk", left)
+	}
+}
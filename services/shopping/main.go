@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"spiceroute/pkg/config"
+	"spiceroute/pkg/database"
+	"spiceroute/pkg/grpcserver"
+	"spiceroute/pkg/ingredients"
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+const defaultListLimit = 20
+
+type server struct {
+	pb.UnimplementedShoppingServiceServer
+	db *gorm.DB
+}
+
+// GenerateShoppingList builds and stores a shopping list covering every
+// dish scheduled in a user's plan
+func (s *server) GenerateShoppingList(ctx context.Context, req *pb.GenerateShoppingListRequest) (*pb.ShoppingList, error) {
+	if req.UserId == "" || req.PlanId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id and plan id are required")
+	}
+
+	var plan models.MealPlan
+	result := s.db.WithContext(ctx).Preload("Entries").
+		Where("id = ? AND user_id = ?", req.PlanId, req.UserId).
+		First(&plan)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "plan %s not found", req.PlanId)
+		}
+		return nil, result.Error
+	}
+
+	servings := make(map[string]int32)
+	var dishIDs []string
+	for _, entry := range plan.Entries {
+		if _, ok := servings[entry.DishID]; !ok {
+			dishIDs = append(dishIDs, entry.DishID)
+		}
+		servings[entry.DishID] += entry.Servings
+	}
+
+	var recipes []models.Recipe
+	if len(dishIDs) > 0 {
+		result = s.db.WithContext(ctx).Preload("StructuredIngredients").
+			Where("id IN ?", dishIDs).
+			Find(&recipes)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+	}
+
+	// Only buy what the pantry cannot cover
+	pantry, err := usablePantry(ctx, s.db, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	list := models.ShoppingList{
+		UserID: req.UserId,
+		PlanID: &plan.ID,
+		Items:  finalize(subtractPantry(aggregate(recipes, servings), pantry)),
+	}
+	result = s.db.WithContext(ctx).Create(&list)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	pbList := listToProto(list)
+	pbList.ExpiringPantryItems = expiringSoon(pantry)
+	return pbList, nil
+}
+
+func (s *server) ListShoppingLists(ctx context.Context, req *pb.ShoppingListQuery) (*pb.ShoppingLists, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	var lists []models.ShoppingList
+	result := s.db.WithContext(ctx).Preload("Items").
+		Where("user_id = ?", req.UserId).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&lists)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var pbLists []*pb.ShoppingList
+	for _, list := range lists {
+		pbLists = append(pbLists, listToProto(list))
+	}
+
+	return &pb.ShoppingLists{Lists: pbLists}, nil
+}
+
+func (s *server) GetShoppingList(ctx context.Context, req *pb.ShoppingListLookup) (*pb.ShoppingList, error) {
+	list, err := s.findList(ctx, req.UserId, req.ListId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.db.WithContext(ctx).Where("list_id = ?", list.ID).Find(&list.Items)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	pantry, err := usablePantry(ctx, s.db, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	pbList := listToProto(list)
+	pbList.ExpiringPantryItems = expiringSoon(pantry)
+	return pbList, nil
+}
+
+// UpsertShoppingItem adds a manual item to a list, or edits an existing
+// one. Edited items are marked manual so the edit is visible to the user.
+func (s *server) UpsertShoppingItem(ctx context.Context, req *pb.ShoppingItemUpdate) (*pb.ShoppingItem, error) {
+	if req.Item == nil || strings.TrimSpace(req.Item.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "item name is required")
+	}
+	if req.Item.Quantity < 0 {
+		return nil, status.Error(codes.InvalidArgument, "item quantity must not be negative")
+	}
+
+	list, err := s.findList(ctx, req.UserId, req.ListId)
+	if err != nil {
+		return nil, err
+	}
+
+	item := models.ShoppingItem{ListID: list.ID}
+	if req.Item.Id != 0 {
+		item, err = s.findItem(ctx, list.ID, req.Item.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item.Name = strings.TrimSpace(req.Item.Name)
+	item.Quantity = req.Item.Quantity
+	item.Unit = ingredients.NormalizeUnit(req.Item.Unit)
+	item.CanonicalID = ingredients.CanonicalID(item.Name)
+	item.Category = req.Item.Category
+	if item.Category == "" {
+		item.Category = ingredients.Aisle(item.CanonicalID)
+	}
+	item.Checked = req.Item.Checked
+	item.Manual = true
+
+	result := s.db.WithContext(ctx).Save(&item)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return itemToProto(item), nil
+}
+
+func (s *server) SetShoppingItemChecked(ctx context.Context, req *pb.ShoppingItemCheck) (*pb.ShoppingItem, error) {
+	list, err := s.findList(ctx, req.UserId, req.ListId)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.findItem(ctx, list.ID, req.ItemId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.db.WithContext(ctx).Model(&item).Update("checked", req.Checked)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	item.Checked = req.Checked
+
+	return itemToProto(item), nil
+}
+
+func (s *server) DeleteShoppingItem(ctx context.Context, req *pb.ShoppingItemRef) (*emptypb.Empty, error) {
+	list, err := s.findList(ctx, req.UserId, req.ListId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := s.db.WithContext(ctx).
+		Where("id = ? AND list_id = ?", req.ItemId, list.ID).
+		Delete(&models.ShoppingItem{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Errorf(codes.NotFound, "item %d not found", req.ItemId)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// findList loads a shopping list without its items, scoped to its owner
+func (s *server) findList(ctx context.Context, userID, listID string) (models.ShoppingList, error) {
+	var list models.ShoppingList
+	if userID == "" || listID == "" {
+		return list, status.Error(codes.InvalidArgument, "user id and list id are required")
+	}
+
+	result := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", listID, userID).First(&list)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return list, status.Errorf(codes.NotFound, "shopping list %s not found", listID)
+		}
+		return list, result.Error
+	}
+
+	return list, nil
+}
+
+// findItem loads one item of a shopping list
+func (s *server) findItem(ctx context.Context, listID string, itemID uint64) (models.ShoppingItem, error) {
+	var item models.ShoppingItem
+	result := s.db.WithContext(ctx).Where("id = ? AND list_id = ?", itemID, listID).First(&item)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return item, status.Errorf(codes.NotFound, "item %d not found", itemID)
+		}
+		return item, result.Error
+	}
+	return item, nil
+}
+
+// listToProto converts a shopping list to protobuf, grouping its items by
+// aisle in walking order
+func listToProto(list models.ShoppingList) *pb.ShoppingList {
+	pbList := &pb.ShoppingList{
+		Id:        list.ID,
+		UserId:    list.UserID,
+		CreatedAt: list.CreatedAt.Format(time.RFC3339),
+	}
+	if list.PlanID != nil {
+		pbList.PlanId = *list.PlanID
+	}
+
+	items := append([]models.ShoppingItem(nil), list.Items...)
+	sortItems(items)
+
+	var aisle *pb.ShoppingAisle
+	for _, item := range items {
+		if aisle == nil || aisle.Category != item.Category {
+			aisle = &pb.ShoppingAisle{Category: item.Category}
+			pbList.Aisles = append(pbList.Aisles, aisle)
+		}
+		aisle.Items = append(aisle.Items, itemToProto(item))
+	}
+
+	return pbList
+}
+
+// itemToProto converts a shopping item to protobuf
+func itemToProto(item models.ShoppingItem) *pb.ShoppingItem {
+	return &pb.ShoppingItem{
+		Id:          uint64(item.ID),
+		Name:        item.Name,
+		Quantity:    item.Quantity,
+		Unit:        item.Unit,
+		Category:    item.Category,
+		Checked:     item.Checked,
+		Manual:      item.Manual,
+		CanonicalId: item.CanonicalID,
+		FromPantry:  item.FromPantry,
+	}
+}
+
+func main() {
+	cfg, err := config.Load("shopping")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	// Initialize database connection
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	// Refuse to start against a schema older than this build
+	if err := database.VerifySchema(db); err != nil {
+		log.Fatal("Database schema check failed:", err)
+	}
+
+	// Start gRPC server
+	grpcServer := grpcserver.NewServer()
+	pb.RegisterShoppingServiceServer(grpcServer, &server{db: db})
+	pb.RegisterPantryServiceServer(grpcServer, &pantryServer{db: db})
+
+	log.Printf("Shopping service starting on %s", cfg.ListenAddr())
+	if err := grpcserver.Serve(grpcServer, cfg, db); err != nil {
+		log.Fatal("Failed to serve:", err)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"spiceroute/pkg/ingredients"
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// defaultExpiryWindow is how many days ahead an item counts as expiring soon
+	defaultExpiryWindow = 3
+)
+
+type pantryServer struct {
+	pb.UnimplementedPantryServiceServer
+	db *gorm.DB
+}
+
+// UpsertPantryItem adds an item to a user's pantry, or updates it when an
+// id is given
+func (s *pantryServer) UpsertPantryItem(ctx context.Context, p *pb.PantryItem) (*pb.PantryItem, error) {
+	if p.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "item name is required")
+	}
+	if p.Quantity < 0 {
+		return nil, status.Error(codes.InvalidArgument, "item quantity must not be negative")
+	}
+
+	item := models.PantryItem{UserID: p.UserId}
+	if p.Id != 0 {
+		result := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", p.Id, p.UserId).First(&item)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return nil, status.Errorf(codes.NotFound, "pantry item %d not found", p.Id)
+			}
+			return nil, result.Error
+		}
+	}
+
+	item.Name = strings.TrimSpace(p.Name)
+	item.CanonicalID = ingredients.CanonicalID(item.Name)
+	item.Quantity = p.Quantity
+	item.Unit = ingredients.NormalizeUnit(p.Unit)
+	item.ExpiresOn = nil
+	if p.ExpiresOn != "" {
+		expires, err := time.Parse(dateLayout, p.ExpiresOn)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid expires_on %q: %v", p.ExpiresOn, err)
+		}
+		item.ExpiresOn = &expires
+	}
+
+	result := s.db.WithContext(ctx).Save(&item)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return pantryItemToProto(item, expiryCutoff(defaultExpiryWindow)), nil
+}
+
+func (s *pantryServer) ListPantryItems(ctx context.Context, q *pb.PantryQuery) (*pb.PantryItems, error) {
+	if q.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	window := int(q.ExpiringWithinDays)
+	if window <= 0 {
+		window = defaultExpiryWindow
+	}
+	cutoff := expiryCutoff(window)
+
+	query := s.db.WithContext(ctx).Where("user_id = ?", q.UserId)
+	if q.ExpiringOnly {
+		// Items past their date are spoiled, not expiring, and must not be
+		// offered for use
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		query = query.Where("expires_on BETWEEN ? AND ?", today, cutoff)
+	}
+
+	var items []models.PantryItem
+	result := query.Order("expires_on ASC NULLS LAST, name").Find(&items)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	resp := &pb.PantryItems{}
+	for _, item := range items {
+		resp.Items = append(resp.Items, pantryItemToProto(item, cutoff))
+	}
+	return resp, nil
+}
+
+func (s *pantryServer) DeletePantryItem(ctx context.Context, ref *pb.PantryItemRef) (*emptypb.Empty, error) {
+	if ref.UserId == "" || ref.ItemId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id and item id are required")
+	}
+
+	result := s.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", ref.ItemId, ref.UserId).
+		Delete(&models.PantryItem{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Errorf(codes.NotFound, "pantry item %d not found", ref.ItemId)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// usablePantry returns a user's pantry items that have not expired yet
+func usablePantry(ctx context.Context, db *gorm.DB, userID string) ([]models.PantryItem, error) {
+	var items []models.PantryItem
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	result := db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Where("expires_on IS NULL OR expires_on >= ?", today).
+		Order("expires_on ASC NULLS LAST, name").
+		Find(&items)
+	return items, result.Error
+}
+
+// expiringSoon picks the usable pantry items that expire within the default
+// window, so they can be flagged for the planner to use up first
+func expiringSoon(items []models.PantryItem) []*pb.PantryItem {
+	cutoff := expiryCutoff(defaultExpiryWindow)
+	var out []*pb.PantryItem
+	for _, item := range items {
+		if item.ExpiresOn != nil && !item.ExpiresOn.After(cutoff) {
+			out = append(out, pantryItemToProto(item, cutoff))
+		}
+	}
+	return out
+}
+
+// expiryCutoff returns the last date that counts as expiring soon
+func expiryCutoff(days int) time.Time {
+	return time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, days)
+}
+
+// pantryItemToProto converts a pantry item to protobuf, flagging it when it
+// expires between today and cutoff. Items already past their date are not
+// flagged, since they are spoiled rather than about to be.
+func pantryItemToProto(item models.PantryItem, cutoff time.Time) *pb.PantryItem {
+	p := &pb.PantryItem{
+		Id:          uint64(item.ID),
+		UserId:      item.UserID,
+		Name:        item.Name,
+		Quantity:    item.Quantity,
+		Unit:        item.Unit,
+		CanonicalId: item.CanonicalID,
+	}
+	if item.ExpiresOn != nil {
+		p.ExpiresOn = item.ExpiresOn.Format(dateLayout)
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		p.ExpiringSoon = !item.ExpiresOn.Before(today) && !item.ExpiresOn.After(cutoff)
+	}
+	return p
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"spiceroute/pkg/config"
+	"spiceroute/pkg/models"
+)
+
+// Embedder turns texts into vectors of a fixed size. Vectors from
+// different embedders, or one embedder with different settings, are not
+// comparable, so each is stored under its own Name.
+type Embedder interface {
+	Name() string
+	Dimensions() int
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// embedders builds each embedder that config.Vector can select. A new
+// embedder is added here and to the accepted names in pkg/config.
+var embedders = map[string]func(cfg config.Vector) (Embedder, error){
+	"hashing": newHashingEmbedder,
+	"openai":  newOpenAIEmbedder,
+}
+
+// newEmbedder builds the configured embedder
+func newEmbedder(cfg config.Vector) (Embedder, error) {
+	build, ok := embedders[cfg.Embedder]
+	if !ok {
+		return nil, fmt.Errorf("unknown embedder %q", cfg.Embedder)
+	}
+	return build(cfg)
+}
+
+// recipeText is the text a recipe is embedded from: its name, cuisine,
+// tags and ingredients
+func recipeText(recipe models.Recipe) string {
+	var b strings.Builder
+	b.WriteString(recipe.Name)
+	if recipe.Cuisine != "" {
+		b.WriteString(". Cuisine: " + recipe.Cuisine)
+	}
+	if len(recipe.Tags) > 0 {
+		b.WriteString(". Tags: " + strings.Join(recipe.Tags, ", "))
+	}
+
+	ingredients := recipe.Ingredients
+	if len(recipe.StructuredIngredients) > 0 {
+		ingredients = nil
+		for _, ing := range recipe.StructuredIngredients {
+			ingredients = append(ingredients, ing.Name)
+		}
+	}
+	if len(ingredients) > 0 {
+		b.WriteString(". Ingredients: " + strings.Join(ingredients, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"spiceroute/pkg/config"
+)
+
+// defaultHashingDimensions is the hashing embedder's vector size unless
+// configured otherwise
+const defaultHashingDimensions = 512
+
+// Feature weights of the hashing embedder. Whole words carry the meaning;
+// word pairs add a little phrase context, and character trigrams let
+// plurals and misspellings land near the word they vary.
+const (
+	wordWeight    = 1.0
+	bigramWeight  = 0.5
+	trigramWeight = 0.3
+)
+
+// stopWords are too common in recipe text to say anything about a recipe
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "of": true, "with": true,
+	"in": true, "on": true, "for": true, "to": true, "or": true,
+	"cuisine": true, "tags": true, "ingredients": true,
+}
+
+// hashingEmbedder embeds text without a model or network by hashing its
+// words, word pairs and character trigrams into a fixed number of
+// buckets, each hash choosing a bucket and a sign. It is deterministic, so
+// vectors stay valid across restarts and replicas, and texts sharing
+// vocabulary end up similar.
+type hashingEmbedder struct {
+	dims int
+}
+
+func newHashingEmbedder(cfg config.Vector) (Embedder, error) {
+	dims := cfg.Dimensions
+	if dims == 0 {
+		dims = defaultHashingDimensions
+	}
+	return &hashingEmbedder{dims: dims}, nil
+}
+
+func (e *hashingEmbedder) Name() string {
+	return fmt.Sprintf("hashing-%d", e.dims)
+}
+
+func (e *hashingEmbedder) Dimensions() int {
+	return e.dims
+}
+
+func (e *hashingEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = e.embed(text)
+	}
+	return vectors, nil
+}
+
+func (e *hashingEmbedder) embed(text string) []float32 {
+	v := make([]float32, e.dims)
+	words := tokenize(text)
+	for i, word := range words {
+		e.add(v, "w:"+word, wordWeight)
+		if i > 0 {
+			e.add(v, "b:"+words[i-1]+" "+word, bigramWeight)
+		}
+		padded := []rune("^" + word + "$")
+		for j := 0; j+3 <= len(padded); j++ {
+			e.add(v, "t:"+string(padded[j:j+3]), trigramWeight)
+		}
+	}
+	normalize(v)
+	return v
+}
+
+// add hashes a feature into its bucket with a sign taken from the hash, so
+// colliding features tend to cancel out rather than pile up
+func (e *hashingEmbedder) add(v []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+
+	bucket := sum % uint64(e.dims)
+	if sum>>63 == 1 {
+		weight = -weight
+	}
+	v[bucket] += weight
+}
+
+// tokenize splits text into lower-case words of letters and digits,
+// dropping stop words
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	words := fields[:0]
+	for _, f := range fields {
+		if !stopWords[f] {
+			words = append(words, f)
+		}
+	}
+	return words
+}
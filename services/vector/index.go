@@ -0,0 +1,170 @@
+package main
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// index is an in-memory brute-force cosine similarity index over recipe
+// vectors. Vectors are normalized on insert, so similarity is a dot
+// product. A linear scan is exact and fast enough for a recipe catalog of
+// tens of thousands.
+type index struct {
+	mu      sync.RWMutex
+	dims    int
+	entries []entry
+	// pos maps a recipe id to its position in entries
+	pos map[string]int
+}
+
+// entry is one recipe in the index
+type entry struct {
+	id      string
+	cuisine string // lower case, for filtering
+	vector  []float32
+}
+
+// match is a recipe found by a search
+type match struct {
+	id         string
+	similarity float64
+}
+
+func newIndex(dims int) *index {
+	return &index{dims: dims, pos: make(map[string]int)}
+}
+
+// put adds a recipe or replaces its vector. Vectors of the wrong size or
+// with no direction are ignored.
+func (ix *index) put(id, cuisine string, vector []float32) bool {
+	if len(vector) != ix.dims || !normalize(vector) {
+		return false
+	}
+	e := entry{id: id, cuisine: strings.ToLower(cuisine), vector: vector}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if i, ok := ix.pos[id]; ok {
+		ix.entries[i] = e
+		return true
+	}
+	ix.pos[id] = len(ix.entries)
+	ix.entries = append(ix.entries, e)
+	return true
+}
+
+// remove drops recipes from the index, moving the last entry into each gap
+func (ix *index) remove(ids ...string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	for _, id := range ids {
+		i, ok := ix.pos[id]
+		if !ok {
+			continue
+		}
+		last := len(ix.entries) - 1
+		ix.entries[i] = ix.entries[last]
+		ix.pos[ix.entries[i].id] = i
+		ix.entries = ix.entries[:last]
+		delete(ix.pos, id)
+	}
+}
+
+// vector returns the stored, normalized vector of a recipe
+func (ix *index) vector(id string) ([]float32, bool) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	i, ok := ix.pos[id]
+	if !ok {
+		return nil, false
+	}
+	return ix.entries[i].vector, true
+}
+
+func (ix *index) len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.entries)
+}
+
+// search returns the k recipes most similar to query, best first. Only
+// recipes of the given cuisines are considered when any are given, and
+// recipes in exclude and those below minSimilarity are skipped.
+func (ix *index) search(query []float32, k int, cuisines []string, exclude map[string]bool, minSimilarity float64) []match {
+	if len(query) != ix.dims || !normalize(query) || k <= 0 {
+		return nil
+	}
+	var allowed map[string]bool
+	if len(cuisines) > 0 {
+		allowed = make(map[string]bool, len(cuisines))
+		for _, c := range cuisines {
+			allowed[strings.ToLower(c)] = true
+		}
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	// top is a min-heap of the best k so far; its root is the worst of them
+	top := make(matchHeap, 0, k)
+	for _, e := range ix.entries {
+		if exclude[e.id] || (allowed != nil && !allowed[e.cuisine]) {
+			continue
+		}
+		sim := dot(query, e.vector)
+		if sim < minSimilarity {
+			continue
+		}
+		if len(top) < k {
+			heap.Push(&top, match{e.id, sim})
+		} else if sim > top[0].similarity {
+			top[0] = match{e.id, sim}
+			heap.Fix(&top, 0)
+		}
+	}
+
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].similarity != top[j].similarity {
+			return top[i].similarity > top[j].similarity
+		}
+		return top[i].id < top[j].id
+	})
+	return top
+}
+
+type matchHeap []match
+
+func (h matchHeap) Len() int            { return len(h) }
+func (h matchHeap) Less(i, j int) bool  { return h[i].similarity < h[j].similarity }
+func (h matchHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *matchHeap) Push(x interface{}) { *h = append(*h, x.(match)) }
+func (h *matchHeap) Pop() interface{} {
+	old := *h
+	m := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return m
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+// normalize scales v to unit length in place. It reports false for a zero
+// vector, which has no direction to compare.
+func normalize(v []float32) bool {
+	norm := math.Sqrt(dot(v, v))
+	if norm == 0 || math.IsNaN(norm) || math.IsInf(norm, 0) {
+		return false
+	}
+	for i := range v {
+		v[i] = float32(float64(v[i]) / norm)
+	}
+	return true
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"spiceroute/pkg/config"
+	"spiceroute/pkg/database"
+	"spiceroute/pkg/grpcserver"
+	"spiceroute/pkg/models"
+	pb "spiceroute/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+	// neutralRating is the rating that says nothing about a user's taste;
+	// ratings above it pull recommendations towards a recipe and ratings
+	// below push them away
+	neutralRating = 3
+)
+
+type server struct {
+	db       *gorm.DB
+	embedder Embedder
+	index    *index
+
+	// syncMu serializes syncs; since, the start of the window the next
+	// sync loads vectors from, is guarded by it
+	syncMu sync.Mutex
+	since  time.Time
+
+	pb.UnimplementedVectorServiceServer
+}
+
+// SearchSimilar finds the recipes closest in meaning to free text
+func (s *server) SearchSimilar(ctx context.Context, req *pb.SemanticSearchRequest) (*pb.SimilarRecipes, error) {
+	if len(tokenize(req.Query)) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "query must contain at least one word")
+	}
+	limit, err := resultLimit(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if req.MinSimilarity > 1 {
+		return nil, status.Error(codes.InvalidArgument, "min_similarity must be at most 1")
+	}
+
+	vectors, err := s.embedder.Embed(ctx, []string{req.Query})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to embed query: %v", err)
+	}
+
+	// Zero leaves the results unfiltered
+	minSimilarity := req.MinSimilarity
+	if minSimilarity == 0 {
+		minSimilarity = math.Inf(-1)
+	}
+	matches := s.index.search(vectors[0], limit, req.Cuisines, nil, minSimilarity)
+	return s.similarRecipes(ctx, matches)
+}
+
+// Recommend finds recipes like the ones a user rated highly, or like the
+// given recipes, leaving out recipes the user has already given feedback
+// on and the given recipes themselves
+func (s *server) Recommend(ctx context.Context, req *pb.RecommendationRequest) (*pb.SimilarRecipes, error) {
+	if req.UserId == "" && len(req.RecipeIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user id or recipe ids are required")
+	}
+	limit, err := resultLimit(req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := make(map[string]float64)
+	exclude := make(map[string]bool)
+	for _, id := range req.RecipeIds {
+		if _, ok := s.index.vector(id); !ok {
+			return nil, status.Errorf(codes.NotFound, "recipe %s is not indexed", id)
+		}
+		weights[id] = 1
+		exclude[id] = true
+	}
+
+	if req.UserId != "" {
+		var ratings []struct {
+			DishID string
+			Rating float64
+		}
+		err := s.db.WithContext(ctx).Model(&models.Feedback{}).
+			Select("dish_id, coalesce(avg(nullif(rating, 0)), 0) AS rating").
+			Where("user_id = ?", req.UserId).
+			Group("dish_id").
+			Scan(&ratings).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range ratings {
+			exclude[r.DishID] = true
+			if r.Rating > 0 {
+				weights[r.DishID] += r.Rating - neutralRating
+			}
+		}
+	}
+
+	// The taste profile is the weighted sum of the seed recipes' vectors
+	profile := make([]float32, s.embedder.Dimensions())
+	liked := false
+	for id, weight := range weights {
+		vector, ok := s.index.vector(id)
+		if !ok {
+			continue // rated but not embedded yet
+		}
+		for i, x := range vector {
+			profile[i] += float32(weight) * x
+		}
+		liked = liked || weight > 0
+	}
+	if !liked {
+		return nil, status.Error(codes.FailedPrecondition, "no highly rated or given recipes to recommend from")
+	}
+
+	matches := s.index.search(profile, limit, nil, exclude, math.Inf(-1))
+	return s.similarRecipes(ctx, matches)
+}
+
+// Reindex embeds new and changed recipes now instead of at the next sync
+func (s *server) Reindex(ctx context.Context, req *pb.ReindexRequest) (*pb.VectorIndexStatus, error) {
+	embedded, err := s.sync(ctx, req.Rebuild)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.VectorIndexStatus{
+		Embedder:   s.embedder.Name(),
+		Dimensions: int32(s.embedder.Dimensions()),
+		Indexed:    int64(s.index.len()),
+		Embedded:   int64(embedded),
+	}, nil
+}
+
+// similarRecipes loads the matched recipes, keeping the match order
+func (s *server) similarRecipes(ctx context.Context, matches []match) (*pb.SimilarRecipes, error) {
+	resp := &pb.SimilarRecipes{Embedder: s.embedder.Name()}
+	if len(matches) == 0 {
+		return resp, nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.id
+	}
+	var recipes []models.Recipe
+	if err := s.db.WithContext(ctx).Where("id IN ?", ids).Find(&recipes).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.Recipe, len(recipes))
+	for _, recipe := range recipes {
+		byID[recipe.ID] = recipe
+	}
+
+	for _, m := range matches {
+		recipe, ok := byID[m.id]
+		if !ok {
+			continue // deleted since the last sync
+		}
+		resp.Recipes = append(resp.Recipes, &pb.SimilarRecipe{
+			Recipe:     recipeToProto(recipe),
+			Similarity: m.similarity,
+		})
+	}
+	return resp, nil
+}
+
+// resultLimit applies the default and maximum number of results
+func resultLimit(limit int32) (int, error) {
+	if limit < 0 {
+		return 0, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	if limit == 0 {
+		return defaultLimit, nil
+	}
+	return min(int(limit), maxLimit), nil
+}
+
+// recipeToProto converts the recipe fields shown with search results;
+// structured ingredients and nutrition facts come from the recipes service
+func recipeToProto(recipe models.Recipe) *pb.Recipe {
+	return &pb.Recipe{
+		Id:            recipe.ID,
+		Name:          recipe.Name,
+		Cuisine:       recipe.Cuisine,
+		PrepMinutes:   recipe.PrepMinutes,
+		Calories:      recipe.Calories,
+		Ingredients:   recipe.Ingredients,
+		Cost:          recipe.Cost,
+		ShelfLifeDays: recipe.ShelfLifeDays,
+		Tags:          recipe.Tags,
+		Nutrition:     recipe.Nutrition,
+	}
+}
+
+func main() {
+	cfg, err := config.Load("vector")
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+
+	// Initialize database connection
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	// Refuse to start against a schema older than this build
+	if err := database.VerifySchema(db); err != nil {
+		log.Fatal("Database schema check failed:", err)
+	}
+
+	embedder, err := newEmbedder(cfg.Vector)
+	if err != nil {
+		log.Fatal("Failed to set up embedder:", err)
+	}
+
+	// Rebuild the index from stored vectors, then keep it in sync with
+	// the recipes in the background
+	s := &server{db: db, embedder: embedder, index: newIndex(embedder.Dimensions())}
+	if err := s.load(context.Background()); err != nil {
+		log.Fatal("Failed to load recipe vectors:", err)
+	}
+	log.Printf("Loaded %d recipe vectors from %s", s.index.len(), embedder.Name())
+	go s.syncLoop(cfg.Vector.SyncInterval)
+
+	// Start gRPC server
+	grpcServer := grpcserver.NewServer()
+	pb.RegisterVectorServiceServer(grpcServer, s)
+
+	log.Printf("Vector service starting on %s", cfg.ListenAddr())
+	if err := grpcserver.Serve(grpcServer, cfg, db); err != nil {
+		log.Fatal("Failed to serve:", err)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"spiceroute/pkg/config"
+)
+
+const (
+	openAIEmbeddingsURL = "https://api.openai.com/v1/embeddings"
+	// defaultOpenAIDimensions is the native size of text-embedding-3-small
+	defaultOpenAIDimensions = 1536
+	openAITimeout           = 30 * time.Second
+)
+
+// openAIEmbedder embeds text with the OpenAI embeddings API
+type openAIEmbedder struct {
+	client *http.Client
+	key    string
+	model  string
+	dims   int
+}
+
+func newOpenAIEmbedder(cfg config.Vector) (Embedder, error) {
+	if cfg.OpenAIKey == "" {
+		return nil, fmt.Errorf("the openai embedder needs an API key")
+	}
+	dims := cfg.Dimensions
+	if dims == 0 {
+		dims = defaultOpenAIDimensions
+	}
+	return &openAIEmbedder{
+		client: &http.Client{Timeout: openAITimeout},
+		key:    cfg.OpenAIKey,
+		model:  cfg.OpenAIModel,
+		dims:   dims,
+	}, nil
+}
+
+func (e *openAIEmbedder) Name() string {
+	return fmt.Sprintf("openai-%s-%d", e.model, e.dims)
+}
+
+func (e *openAIEmbedder) Dimensions() int {
+	return e.dims
+}
+
+type openAIRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions"`
+}
+
+type openAIResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(openAIRequest{Model: e.model, Input: texts, Dimensions: e.dims})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, openAIEmbeddingsURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+e.key)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("openai embeddings request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result openAIResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<20)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("openai embeddings returned %s: %w", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if result.Error != nil {
+			return nil, fmt.Errorf("openai embeddings returned %s: %s", resp.Status, result.Error.Message)
+		}
+		return nil, fmt.Errorf("openai embeddings returned %s", resp.Status)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= len(texts) || len(d.Embedding) != e.dims {
+			return nil, fmt.Errorf("openai embeddings returned an unexpected embedding")
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("openai embeddings returned no embedding for input %d", i)
+		}
+	}
+	return vectors, nil
+}
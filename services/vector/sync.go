@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"spiceroute/pkg/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// embedBatchSize is how many recipes are embedded per call to the
+	// embedder and stored per transaction
+	embedBatchSize = 64
+	// syncOverlap widens each sync's window back in time so vectors
+	// committed by a slow transaction on another replica are not missed
+	syncOverlap = time.Minute
+	// minUUID sorts before every recipe id, to start paging through them
+	minUUID = "00000000-0000-0000-0000-000000000000"
+)
+
+// upsertEmbeddingSQL stores a recipe's vector, stamping it with the
+// database clock that the next sync's window is measured against
+const upsertEmbeddingSQL = `
+INSERT INTO recipe_embeddings (recipe_id, embedder, dimensions, vector, source_updated_at, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, now(), now())
+ON CONFLICT (recipe_id, embedder) DO UPDATE SET
+	dimensions = excluded.dimensions,
+	vector = excluded.vector,
+	source_updated_at = excluded.source_updated_at,
+	updated_at = now()`
+
+// load fills the index with every stored vector of live recipes. It runs
+// before the server starts, so queries are answered from the start without
+// waiting for recipes to be embedded.
+func (s *server) load(ctx context.Context) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	started, err := s.dbNow(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.loadSince(ctx, time.Time{}); err != nil {
+		return err
+	}
+	s.since = started.Add(-syncOverlap)
+	return nil
+}
+
+// sync brings the index up to date with the recipes table. It embeds live
+// recipes that have no vector from this embedder or changed after theirs
+// was made (every recipe when rebuild is set), then loads the vectors
+// written since the last sync, by this replica or another, and drops
+// recipes deleted since. It returns how many recipes it embedded.
+func (s *server) sync(ctx context.Context, rebuild bool) (int, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	started, err := s.dbNow(ctx)
+	if err != nil {
+		return 0, err
+	}
+	embedded, err := s.embedStale(ctx, rebuild)
+	if err != nil {
+		return embedded, err
+	}
+	if err := s.loadSince(ctx, s.since); err != nil {
+		return embedded, err
+	}
+	if err := s.removeDeleted(ctx, s.since); err != nil {
+		return embedded, err
+	}
+	s.since = started.Add(-syncOverlap)
+	return embedded, nil
+}
+
+// syncLoop syncs now and then every interval, logging failures; a failed
+// sync is retried at the next tick
+func (s *server) syncLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		embedded, err := s.sync(context.Background(), false)
+		if err != nil {
+			log.Println("Vector index sync failed:", err)
+		} else if embedded > 0 {
+			log.Printf("Embedded %d recipes, %d indexed", embedded, s.index.len())
+		}
+		<-ticker.C
+	}
+}
+
+// dbNow returns the database clock, which the stored vectors are stamped with
+func (s *server) dbNow(ctx context.Context) (time.Time, error) {
+	var now time.Time
+	err := s.db.WithContext(ctx).Raw("SELECT now()").Scan(&now).Error
+	return now, err
+}
+
+// embedStale embeds and stores recipes in batches, paging by id
+func (s *server) embedStale(ctx context.Context, rebuild bool) (int, error) {
+	embedded := 0
+	after := minUUID
+	for {
+		query := s.db.WithContext(ctx).
+			Preload("StructuredIngredients", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+			Select("recipes.*").
+			Joins("LEFT JOIN recipe_embeddings e ON e.recipe_id = recipes.id AND e.embedder = ?", s.embedder.Name()).
+			Where("recipes.id > ?", after)
+		if !rebuild {
+			query = query.Where("e.recipe_id IS NULL OR e.source_updated_at < recipes.updated_at")
+		}
+
+		var recipes []models.Recipe
+		if err := query.Order("recipes.id").Limit(embedBatchSize).Find(&recipes).Error; err != nil {
+			return embedded, err
+		}
+		if len(recipes) == 0 {
+			return embedded, nil
+		}
+
+		texts := make([]string, len(recipes))
+		for i, recipe := range recipes {
+			texts[i] = recipeText(recipe)
+		}
+		vectors, err := s.embedder.Embed(ctx, texts)
+		if err != nil {
+			return embedded, fmt.Errorf("failed to embed recipes: %w", err)
+		}
+
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for i, recipe := range recipes {
+				err := tx.Exec(upsertEmbeddingSQL, recipe.ID, s.embedder.Name(), len(vectors[i]),
+					encodeVector(vectors[i]), recipe.UpdatedAt).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return embedded, err
+		}
+
+		embedded += len(recipes)
+		after = recipes[len(recipes)-1].ID
+	}
+}
+
+// loadSince puts the vectors of live recipes stored since the given time
+// into the index
+func (s *server) loadSince(ctx context.Context, since time.Time) error {
+	rows, err := s.db.WithContext(ctx).
+		Table("recipe_embeddings e").
+		Select("e.recipe_id, r.cuisine, e.vector").
+		Joins("JOIN recipes r ON r.id = e.recipe_id AND r.deleted_at IS NULL").
+		Where("e.embedder = ? AND e.updated_at >= ?", s.embedder.Name(), since).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, cuisine string
+		var data []byte
+		if err := rows.Scan(&id, &cuisine, &data); err != nil {
+			return err
+		}
+		vector, err := decodeVector(data, s.embedder.Dimensions())
+		if err != nil {
+			log.Printf("Skipping stored vector of recipe %s: %v", id, err)
+			continue
+		}
+		s.index.put(id, cuisine, vector)
+	}
+	return rows.Err()
+}
+
+// removeDeleted drops recipes deleted since the given time from the index
+// and forgets their vectors, so a restored recipe is embedded afresh
+func (s *server) removeDeleted(ctx context.Context, since time.Time) error {
+	var ids []string
+	err := s.db.WithContext(ctx).Unscoped().Model(&models.Recipe{}).
+		Where("deleted_at >= ?", since).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	s.index.remove(ids...)
+	return s.db.WithContext(ctx).Where("recipe_id IN ?", ids).Delete(&models.RecipeEmbedding{}).Error
+}
+
+// encodeVector packs a vector as little-endian float32s
+func encodeVector(v []float32) []byte {
+	data := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(x))
+	}
+	return data
+}
+
+// decodeVector unpacks a vector stored by encodeVector
+func decodeVector(data []byte, dims int) ([]float32, error) {
+	if len(data) != 4*dims {
+		return nil, fmt.Errorf("vector has %d bytes, expected %d", len(data), 4*dims)
+	}
+	v := make([]float32, dims)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return v, nil
+}